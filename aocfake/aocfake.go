@@ -0,0 +1,186 @@
+// Package aocfake provides a fake Advent of Code server for testing things that talk to the site.
+//
+// The server is built from fixtures and serves puzzle pages, inputs, answer responses,
+// leaderboards, and the pages shown to users that aren't logged in.
+// It can be used with net/http/httptest:
+//
+//	fake := aocfake.New()
+//	server := httptest.NewServer(fake)
+//	defer server.Close()
+//
+// Requests are only treated as logged in if they send the fake's Token as the session cookie.
+package aocfake
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TOKEN is the session token the fake server accepts by default
+const TOKEN = "aocfake-session-token"
+
+// Default display name of the fake server's user
+const USER_NAME = "Fake Elf"
+
+// Default ID of the private leaderboard the fake server's user belongs to
+const PRIVATE_LB_ID = "12345"
+
+// Default amount of time the user is locked out after a wrong answer
+const WRONG_ANSWER_LOCKOUT = time.Minute
+
+// Puzzle is a single day's puzzle on the fake server
+type Puzzle struct {
+	Year  int    `json:"year"`
+	Day   int    `json:"day"`
+	Title string `json:"title"`
+
+	// HTML contents of each part's article, without the title
+	PartOne string `json:"partOne"`
+	PartTwo string `json:"partTwo"`
+
+	AnswerOne string `json:"answerOne"`
+	AnswerTwo string `json:"answerTwo"`
+
+	Input string `json:"-"`
+
+	// Number of parts the user has solved
+	Solved int `json:"solved"`
+}
+
+// Server is a fake Advent of Code server. Use New to create one.
+type Server struct {
+	// Session token that's treated as logged in
+	Token string
+	// Display name of the logged in user
+	UserName string
+	// ID of the private leaderboard the user belongs to
+	PrivateLeaderboardID string
+	// How long the user is locked out after a wrong answer
+	Lockout time.Duration
+
+	mu         sync.Mutex
+	mux        *http.ServeMux
+	puzzles    map[string]*Puzzle
+	lockoutEnd time.Time
+	now        func() time.Time
+}
+
+// New creates a fake server loaded with the default puzzle fixtures
+func New() *Server {
+	s := &Server{
+		Token:                TOKEN,
+		UserName:             USER_NAME,
+		PrivateLeaderboardID: PRIVATE_LB_ID,
+		Lockout:              WRONG_ANSWER_LOCKOUT,
+		puzzles:              make(map[string]*Puzzle),
+		now:                  time.Now,
+	}
+
+	for _, p := range loadPuzzleFixtures() {
+		s.AddPuzzle(p)
+	}
+
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("GET /{$}", s.handleHome)
+	s.mux.HandleFunc("GET /auth/login", s.handleLogin)
+	s.mux.HandleFunc("GET /{year}", s.handleCalendar)
+	s.mux.HandleFunc("GET /{year}/{$}", s.handleCalendar)
+	s.mux.HandleFunc("GET /{year}/day/{day}", s.handlePuzzle)
+	s.mux.HandleFunc("GET /{year}/day/{day}/input", s.handleInput)
+	s.mux.HandleFunc("POST /{year}/day/{day}/answer", s.handleAnswer)
+	s.mux.HandleFunc("GET /{year}/leaderboard", s.handleLeaderboard)
+	s.mux.HandleFunc("GET /{year}/leaderboard/day/{day}", s.handleLeaderboard)
+	s.mux.HandleFunc("GET /{year}/leaderboard/private", s.handleLogin)
+	s.mux.HandleFunc("GET /{year}/leaderboard/private/view/{id}", s.handlePrivateLeaderboard)
+
+	return s
+}
+
+// ServeHTTP lets the fake server be used as an http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// AddPuzzle adds a puzzle to the server, replacing any existing puzzle on the same day
+func (s *Server) AddPuzzle(p *Puzzle) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.puzzles[puzzleKey(p.Year, p.Day)] = p
+}
+
+// GetPuzzle returns the puzzle for a given day, or nil if there isn't one
+func (s *Server) GetPuzzle(year, day int) *Puzzle {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.puzzles[puzzleKey(year, day)]
+}
+
+// SetLockout locks the user out of submitting answers for a given duration
+func (s *Server) SetLockout(duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lockoutEnd = s.now().Add(duration)
+}
+
+// Stars returns how many stars the user has obtained
+func (s *Server) Stars() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stars := 0
+	for _, p := range s.puzzles {
+		stars += p.Solved
+	}
+	return stars
+}
+
+func puzzleKey(year, day int) string {
+	return fmt.Sprintf("%v-%v", year, day)
+}
+
+// Returns true if the request has the server's session token
+func (s *Server) isLoggedIn(r *http.Request) bool {
+	cookie, err := r.Cookie("session")
+	return err == nil && strings.TrimSpace(cookie.Value) == s.Token
+}
+
+// Parses the year and day out of a request's path. Day is 0 if not in the path.
+func parseDate(r *http.Request) (int, int, error) {
+	year, err := strconv.Atoi(r.PathValue("year"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if r.PathValue("day") == "" {
+		return year, 0, nil
+	}
+
+	day, err := strconv.Atoi(r.PathValue("day"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return year, day, nil
+}
+
+// Loads the puzzle a request is for, writing a 404 if there isn't one
+func (s *Server) requestPuzzle(w http.ResponseWriter, r *http.Request) *Puzzle {
+	year, day, err := parseDate(r)
+	if err != nil {
+		http.NotFound(w, r)
+		return nil
+	}
+
+	puzzle := s.GetPuzzle(year, day)
+	if puzzle == nil {
+		// Same as the site's response to requesting a locked puzzle
+		http.Error(w, "Please don't repeatedly request this endpoint before it unlocks! The calendar countdown is synchronized with the server time; the link will be enabled on the calendar the instant this puzzle becomes available.", http.StatusNotFound)
+		return nil
+	}
+
+	return puzzle
+}
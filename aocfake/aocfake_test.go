@@ -0,0 +1,121 @@
+package aocfake
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Makes a request to the fake server, logged in with its token unless the token is empty
+func request(t *testing.T, server *httptest.Server, method, path, token string, form url.Values) (int, string) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequest(method, server.URL+path, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if token != "" {
+		req.AddCookie(&http.Cookie{Name: "session", Value: token})
+	}
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(data)
+}
+
+func TestServerInput(t *testing.T) {
+	fake := New()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	if status, body := request(t, server, http.MethodGet, "/2015/day/1/input", fake.Token, nil); status != http.StatusOK || body != fake.GetPuzzle(2015, 1).Input {
+		t.Errorf("Expected the puzzle's input, got %v: %q", status, body)
+	}
+
+	if status, body := request(t, server, http.MethodGet, "/2015/day/1/input", "", nil); status != http.StatusBadRequest || !strings.Contains(body, "Please log in") {
+		t.Errorf("Expected to be told to log in, got %v: %q", status, body)
+	}
+
+	if status, _ := request(t, server, http.MethodGet, "/2015/day/3/input", fake.Token, nil); status != http.StatusNotFound {
+		t.Errorf("Expected a locked puzzle to 404, got %v", status)
+	}
+}
+
+func TestServerAnswer(t *testing.T) {
+	fake := New()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	answer := func(level, answer string) string {
+		_, body := request(t, server, http.MethodPost, "/2015/day/2/answer", fake.Token, url.Values{"level": {level}, "answer": {answer}})
+		return body
+	}
+
+	if body := answer("1", "1"); !strings.Contains(body, "your answer is too low") || !strings.Contains(body, "one minute") {
+		t.Errorf("Expected a too low answer with a lockout, got %q", body)
+	}
+
+	if body := answer("1", "91"); !strings.Contains(body, "You gave an answer too recently") {
+		t.Errorf("Expected to still be locked out, got %q", body)
+	}
+
+	fake.SetLockout(0)
+	if body := answer("2", "16"); !strings.Contains(body, "You don't seem to be solving the right level") {
+		t.Errorf("Expected part two to be the wrong level, got %q", body)
+	}
+
+	if body := answer("1", "91"); !strings.Contains(body, "That's the right answer!") || fake.Stars() != 1 {
+		t.Errorf("Expected the right answer and a star, got %v stars and %q", fake.Stars(), body)
+	}
+
+	// The solved part's answer is shown on the puzzle page, but only to the logged in user
+	if _, body := request(t, server, http.MethodGet, "/2015/day/2", fake.Token, nil); !strings.Contains(body, "<code>91</code>") {
+		t.Errorf("Expected the puzzle page to show the answer, got %q", body)
+	}
+	if _, body := request(t, server, http.MethodGet, "/2015/day/2", "", nil); strings.Contains(body, "<code>91</code>") {
+		t.Errorf("Expected the answer to be hidden when logged out")
+	}
+
+	if status, _ := request(t, server, http.MethodPost, "/2015/day/2/answer", "", url.Values{"level": {"2"}, "answer": {"16"}}); status != http.StatusFound {
+		t.Errorf("Expected answers from logged out users to redirect to the login page, got %v", status)
+	}
+}
+
+func TestServerCalendar(t *testing.T) {
+	fake := New()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	calendarDay := regexp.MustCompile(`class="calendar-day\d+`)
+
+	var tests = []struct {
+		year, days int
+	}{
+		{2015, 25},
+		{2025, 12},
+	}
+
+	for _, test := range tests {
+		_, body := request(t, server, http.MethodGet, "/"+strconv.Itoa(test.year), fake.Token, nil)
+		if days := len(calendarDay.FindAllString(body, -1)); days != test.days {
+			t.Errorf("Expected %v days on %v's calendar, got %v", test.days, test.year, days)
+		}
+	}
+}
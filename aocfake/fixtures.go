@@ -0,0 +1,92 @@
+package aocfake
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+)
+
+//go:embed fixtures
+var fixtureFS embed.FS
+
+var templates = template.Must(template.ParseFS(fixtureFS, "fixtures/templates/*.html"))
+
+// Loads the default puzzles, along with their inputs
+func loadPuzzleFixtures() []*Puzzle {
+	data, err := fixtureFS.ReadFile("fixtures/puzzles.json")
+	if err != nil {
+		panic(err)
+	}
+
+	var puzzles []*Puzzle
+	if err := json.Unmarshal(data, &puzzles); err != nil {
+		panic(err)
+	}
+
+	for _, p := range puzzles {
+		input, err := fixtureFS.ReadFile(fmt.Sprintf("fixtures/inputs/%v-%v.txt", p.Year, p.Day))
+		if err != nil {
+			panic(err)
+		}
+		p.Input = string(input)
+	}
+
+	return puzzles
+}
+
+// Data shared by every page's header
+type pageData struct {
+	PageTitle string
+	Year      int
+	User      string
+	Stars     int
+}
+
+type puzzlePageData struct {
+	pageData
+	Day       int
+	Title     string
+	PartOne   template.HTML
+	PartTwo   template.HTML
+	AnswerOne string
+	AnswerTwo string
+	Level     int
+}
+
+type answerPageData struct {
+	pageData
+	Day     int
+	Message template.HTML
+}
+
+type calendarDay struct {
+	Day      int
+	Unlocked bool
+
+	// Star state, in the calendar's aria-label and class
+	Label string
+	Class string
+
+	// Half of the day's row of art, on either side of its ornament
+	Art string
+}
+
+type calendarPageData struct {
+	pageData
+	Days []calendarDay
+}
+
+type leaderboardEntry struct {
+	UserID   string
+	Position string
+	Score    int
+	Time     string
+	Name     string
+}
+
+type leaderboardPageData struct {
+	pageData
+	Day     int
+	Entries []leaderboardEntry
+}
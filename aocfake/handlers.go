@@ -0,0 +1,329 @@
+package aocfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.dalton.dog/aocgo/internal/utils"
+)
+
+// Messages the site responds with after submitting an answer
+const (
+	correctMessage     = "That's the right answer!  You are <span class=\"day-success\">one gold star</span> closer to saving Christmas."
+	wrongMessage       = "That's not the right answer.  If you're stuck, make sure you're using the full input data; there are also some general tips on the about page, or you can ask for hints on the subreddit.  Please wait %v before trying again."
+	tooHighMessage     = "That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data; there are also some general tips on the about page, or you can ask for hints on the subreddit.  Please wait %v before trying again."
+	tooLowMessage      = "That's not the right answer; your answer is too low.  If you're stuck, make sure you're using the full input data; there are also some general tips on the about page, or you can ask for hints on the subreddit.  Please wait %v before trying again."
+	tooRecentMessage   = "You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have %v left to wait."
+	wrongLevelMessage  = "You don't seem to be solving the right level.  Did you already complete it?"
+	puzzleInputMessage = "Puzzle inputs differ by user.  Please log in to get your puzzle input.\n"
+)
+
+func (s *Server) getPageData(r *http.Request, title string, year int) pageData {
+	data := pageData{PageTitle: title, Year: year}
+	if s.isLoggedIn(r) {
+		data.User = s.UserName
+		data.Stars = s.Stars()
+	}
+	return data
+}
+
+func (s *Server) render(w http.ResponseWriter, name string, data any) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := templates.ExecuteTemplate(w, name, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleHome(w http.ResponseWriter, r *http.Request) {
+	s.render(w, "home.html", s.getPageData(r, "Advent of Code", s.now().Year()))
+}
+
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	s.render(w, "login.html", s.getPageData(r, "Log In", s.now().Year()))
+}
+
+// Shows a calendar with a row of art for each day, where the days the fake has puzzles for are unlocked.
+// Like the site, the newest day is at the top.
+func (s *Server) handleCalendar(w http.ResponseWriter, r *http.Request) {
+	year, _, err := parseDate(r)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	data := calendarPageData{pageData: s.getPageData(r, "Calendar", year)}
+	for day := utils.GetNumDays(year); day >= 1; day-- {
+		calDay := calendarDay{Day: day, Art: strings.Repeat("~", day%7+3)}
+
+		if puzzle := s.GetPuzzle(year, day); puzzle != nil {
+			calDay.Unlocked = true
+
+			s.mu.Lock()
+			solved := puzzle.Solved
+			s.mu.Unlock()
+
+			// Only the logged in user gets to see their stars
+			if data.User != "" && solved == 1 {
+				calDay.Label, calDay.Class = ", one star", " calendar-complete"
+			} else if data.User != "" && solved >= 2 {
+				calDay.Label, calDay.Class = ", two stars", " calendar-verycomplete"
+			}
+		}
+
+		data.Days = append(data.Days, calDay)
+	}
+
+	s.render(w, "calendar.html", data)
+}
+
+func (s *Server) handlePuzzle(w http.ResponseWriter, r *http.Request) {
+	puzzle := s.requestPuzzle(w, r)
+	if puzzle == nil {
+		return
+	}
+
+	s.mu.Lock()
+	data := puzzlePageData{
+		Day:     puzzle.Day,
+		Title:   puzzle.Title,
+		PartOne: template.HTML(puzzle.PartOne),
+		Level:   puzzle.Solved + 1,
+	}
+	solved := puzzle.Solved
+	s.mu.Unlock()
+
+	data.pageData = s.getPageData(r, fmt.Sprintf("Day %v", puzzle.Day), puzzle.Year)
+
+	// Only the logged in user gets to see their progress
+	if data.User != "" {
+		if solved >= 1 {
+			data.AnswerOne = puzzle.AnswerOne
+			data.PartTwo = template.HTML(puzzle.PartTwo)
+		}
+		if solved >= 2 {
+			data.AnswerTwo = puzzle.AnswerTwo
+		}
+	}
+
+	s.render(w, "puzzle.html", data)
+}
+
+func (s *Server) handleInput(w http.ResponseWriter, r *http.Request) {
+	puzzle := s.requestPuzzle(w, r)
+	if puzzle == nil {
+		return
+	}
+
+	if !s.isLoggedIn(r) {
+		http.Error(w, puzzleInputMessage, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	io.WriteString(w, puzzle.Input)
+}
+
+func (s *Server) handleAnswer(w http.ResponseWriter, r *http.Request) {
+	puzzle := s.requestPuzzle(w, r)
+	if puzzle == nil {
+		return
+	}
+
+	if !s.isLoggedIn(r) {
+		http.Redirect(w, r, "/auth/login", http.StatusFound)
+		return
+	}
+
+	level, _ := strconv.Atoi(r.FormValue("level"))
+	answer := strings.TrimSpace(r.FormValue("answer"))
+
+	data := answerPageData{
+		pageData: s.getPageData(r, fmt.Sprintf("Day %v", puzzle.Day), puzzle.Year),
+		Day:      puzzle.Day,
+		Message:  template.HTML(s.checkAnswer(puzzle, level, answer)),
+	}
+
+	s.render(w, "answer.html", data)
+}
+
+// Checks an answer against the puzzle, updating the user's progress and lockout
+func (s *Server) checkAnswer(puzzle *Puzzle, level int, answer string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Before(s.lockoutEnd) {
+		left := s.lockoutEnd.Sub(now).Round(time.Second)
+		return fmt.Sprintf(tooRecentMessage, formatWait(left))
+	}
+
+	if level != puzzle.Solved+1 {
+		return wrongLevelMessage
+	}
+
+	expected := puzzle.AnswerOne
+	if level == 2 {
+		expected = puzzle.AnswerTwo
+	}
+
+	if answer == expected {
+		puzzle.Solved = level
+		return correctMessage
+	}
+
+	s.lockoutEnd = now.Add(s.Lockout)
+	wait := formatLockout(s.Lockout)
+
+	answerNum, answerErr := strconv.Atoi(answer)
+	expectedNum, expectedErr := strconv.Atoi(expected)
+	if answerErr != nil || expectedErr != nil {
+		return fmt.Sprintf(wrongMessage, wait)
+	} else if answerNum > expectedNum {
+		return fmt.Sprintf(tooHighMessage, wait)
+	}
+	return fmt.Sprintf(tooLowMessage, wait)
+}
+
+// Formats the remaining wait the same way the site does, like "1m 30s"
+func formatWait(d time.Duration) string {
+	minutes := int(d.Minutes())
+	seconds := int(d.Seconds()) % 60
+	if minutes > 0 {
+		return fmt.Sprintf("%vm %vs", minutes, seconds)
+	}
+	return fmt.Sprintf("%vs", seconds)
+}
+
+// Formats a lockout the same way the site does, like "one minute" or "5 minutes"
+func formatLockout(d time.Duration) string {
+	minutes := int(d.Minutes())
+	if minutes <= 1 {
+		return "one minute"
+	}
+	return fmt.Sprintf("%v minutes", minutes)
+}
+
+func (s *Server) handleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	year, day, err := parseDate(r)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	data := leaderboardPageData{
+		pageData: s.getPageData(r, "Leaderboard", year),
+		Day:      day,
+	}
+
+	if day == 0 {
+		for i := 1; i <= 100; i++ {
+			data.Entries = append(data.Entries, leaderboardEntry{
+				UserID:   strconv.Itoa(i),
+				Position: fmt.Sprintf("%3d)", i),
+				Score:    5000 - i*10,
+				Name:     fmt.Sprintf("Elf %v", i),
+			})
+		}
+	} else {
+		// First hundred to get both stars, followed by the first hundred to get the first star
+		for _, offset := range []int{100, 0} {
+			for i := 1; i <= 100; i++ {
+				finish := time.Duration(offset+i) * 7 * time.Second
+				data.Entries = append(data.Entries, leaderboardEntry{
+					UserID:   strconv.Itoa(offset + i),
+					Position: fmt.Sprintf("%3d)", i),
+					Time:     fmt.Sprintf("Dec %02d  %02d:%02d:%02d", day, int(finish.Hours()), int(finish.Minutes())%60, int(finish.Seconds())%60),
+					Name:     fmt.Sprintf("Elf %v", offset+i),
+				})
+			}
+		}
+	}
+
+	s.render(w, "leaderboard.html", data)
+}
+
+type privateStar struct {
+	GetStarTS int64 `json:"get_star_ts"`
+	StarIndex int64 `json:"star_index"`
+}
+
+type privateMember struct {
+	ID                 int                               `json:"id"`
+	Name               *string                           `json:"name"`
+	Stars              int                               `json:"stars"`
+	LocalScore         int                               `json:"local_score"`
+	GlobalScore        int                               `json:"global_score"`
+	LastStarTS         int64                             `json:"last_star_ts"`
+	CompletionDayLevel map[string]map[string]privateStar `json:"completion_day_level"`
+}
+
+func (s *Server) handlePrivateLeaderboard(w http.ResponseWriter, r *http.Request) {
+	year, _, err := parseDate(r)
+	if err != nil || r.PathValue("id") != s.PrivateLeaderboardID+".json" {
+		http.NotFound(w, r)
+		return
+	}
+
+	// Users that aren't logged in (or aren't members) get sent to the private leaderboard page
+	if !s.isLoggedIn(r) {
+		http.Redirect(w, r, fmt.Sprintf("/%v/leaderboard/private", year), http.StatusFound)
+		return
+	}
+
+	userName := s.UserName
+	members := map[string]*privateMember{
+		"1": {ID: 1, Name: &userName, CompletionDayLevel: make(map[string]map[string]privateStar)},
+		"2": {ID: 2, Name: nil, CompletionDayLevel: make(map[string]map[string]privateStar)},
+	}
+
+	s.mu.Lock()
+	var days []int
+	for _, p := range s.puzzles {
+		if p.Year == year {
+			days = append(days, p.Day)
+		}
+	}
+	sort.Ints(days)
+
+	for _, day := range days {
+		p := s.puzzles[puzzleKey(year, day)]
+		release := time.Date(year, time.December, day, 5, 0, 0, 0, time.UTC)
+
+		// The anonymous member always gets both stars an hour in
+		members["2"].addStar(day, 1, release.Add(30*time.Minute))
+		members["2"].addStar(day, 2, release.Add(time.Hour))
+
+		for part := 1; part <= p.Solved; part++ {
+			members["1"].addStar(day, part, release.Add(time.Duration(part)*45*time.Minute))
+		}
+	}
+	s.mu.Unlock()
+
+	// The anonymous member always finishes first, so they get two points per star
+	members["1"].LocalScore = members["1"].Stars
+	members["2"].LocalScore = members["2"].Stars * 2
+
+	json.NewEncoder(w).Encode(map[string]any{
+		"event":    strconv.Itoa(year),
+		"owner_id": 1,
+		"members":  members,
+	})
+}
+
+func (m *privateMember) addStar(day, part int, when time.Time) {
+	dayKey := strconv.Itoa(day)
+	if m.CompletionDayLevel[dayKey] == nil {
+		m.CompletionDayLevel[dayKey] = make(map[string]privateStar)
+	}
+
+	m.CompletionDayLevel[dayKey][strconv.Itoa(part)] = privateStar{GetStarTS: when.Unix(), StarIndex: int64(day*10 + part)}
+	m.Stars++
+	m.LastStarTS = max(m.LastStarTS, when.Unix())
+}
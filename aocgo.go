@@ -3,9 +3,11 @@ package aocgo
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"go.dalton.dog/aocgo/internal/api"
 	"go.dalton.dog/aocgo/internal/cache"
 	"go.dalton.dog/aocgo/internal/resources"
 	"go.dalton.dog/aocgo/internal/session"
@@ -15,18 +17,32 @@ import (
 	"github.com/charmbracelet/log"
 )
 
+// Errors that can be returned while loading puzzle input.
+// Check for them with errors.Is.
+var (
+	ErrNoToken      = session.ErrNoToken
+	ErrInvalidToken = resources.ErrInvalidToken
+	ErrPuzzleLocked = resources.ErrPuzzleLocked
+	ErrRateLimited  = api.ErrRateLimited
+	ErrOffline      = api.ErrOffline
+)
+
+// NotCachedError is returned in offline mode when the input hasn't been cached yet.
+// Check for it with errors.As.
+type NotCachedError = cache.NotCachedError
+
 var correctTestColor = lipgloss.Color("#1d8509")
 var incorrectTestColor = lipgloss.Color("#c40e4f")
 var puzzleSolveColor = lipgloss.Color("#674dd9")
 
 // InputData interface is a Generic wrapper around the possible forms you can get puzzle input data in
 type InputData interface {
-	string | []string | [][]string | []byte
+	string | []string | [][]string | []byte | [][]rune | []int
 }
 
 // AnswerData interface is for any form that the puzzle answer can be output as
 type AnswerData interface {
-	int | string
+	int | int64 | uint64 | string
 }
 
 // Func is a function that will take in
@@ -34,6 +50,7 @@ type Solver[input InputData, answer AnswerData] func(input) answer
 
 // RunTest will run a given function with the given input, and compare it against a known output.
 // The result will print with a color based on if your function returns the same result as was expected.
+// If AOCGO_OUTPUT is set to "json", the result is printed as a single line of JSON instead.
 func RunTest[In InputData, Out AnswerData](title string, solver Solver[In, Out], inputData In, expected Out) {
 	start := time.Now()
 
@@ -46,49 +63,47 @@ func RunTest[In InputData, Out AnswerData](title string, solver Solver[In, Out],
 
 	expectedStr := fmt.Sprintf("%v", expected)
 
-	var outColor lipgloss.Color
+	if jsonOutput() {
+		printJSONResult(Result{
+			Kind:     TEST_RESULT,
+			Title:    title,
+			Part:     partFromTitle(title),
+			Answer:   answerStr,
+			Expected: expectedStr,
+			Passed:   answerStr == expectedStr,
+			Runtime:  timeTaken,
+		})
+		return
+	}
 
+	outColor := incorrectTestColor
 	if answerStr == expectedStr {
 		outColor = correctTestColor
-	} else {
-		outColor = incorrectTestColor
 	}
 
-	// Create styles using lipgloss
-	titleStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(outColor).
-		Padding(0, 1).Margin(1, 0, 0).
-		AlignHorizontal(lipgloss.Center)
-
-	borderStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(outColor).
-		Padding(0, 2).
-		MaxWidth(18)
-
 	// Pretty info
 	prettyAnswer := fmt.Sprintf("Answer  : %v\n", answerStr)
 	prettyExpected := fmt.Sprintf("Expected: %v\n", expectedStr)
 	prettyTime := fmt.Sprintf("Runtime : %v", timeTaken)
 
-	// Render the answer inside the border
-	wrappedInfo := borderStyle.Render(prettyAnswer + prettyExpected + prettyTime)
-
-	// Render the title
-	titleBox := titleStyle.Width(lipgloss.Width(wrappedInfo)).Render(title)
-
-	// Combine the title and wrapped answer
-	output := lipgloss.JoinVertical(lipgloss.Top, titleBox, wrappedInfo)
-
-	// Display the output
-	fmt.Println(output)
+	fmt.Println(renderBox(title, prettyAnswer+prettyExpected+prettyTime, outColor))
 }
 
 // RunSolve will attempt to run the input function with the input data.
 // It will print out information about the function run in a pretty table.
+// If AOCGO_OUTPUT is set to "json", the result is printed as a single line of JSON instead.
+// If AOCGO_BENCH_RUNS or AOCGO_BENCH_TIME are set, the solver is benchmarked like with Benchmark.
+// If AOC_PART is set to a different part than the one in the title, the solver isn't run.
 func RunSolve[In InputData, Out AnswerData](title string, solver Solver[In, Out], inputData In) {
+	if !partRequested(partFromTitle(title)) {
+		return
+	}
+
+	if benchmarkRequested() {
+		Benchmark(title, solver, inputData)
+		return
+	}
+
 	start := time.Now()
 
 	answer := solver(inputData)
@@ -98,45 +113,53 @@ func RunSolve[In InputData, Out AnswerData](title string, solver Solver[In, Out]
 	// Convert the answer to a string
 	answerStr := fmt.Sprintf("%v", answer)
 
-	// Create styles using lipgloss
+	if jsonOutput() {
+		printJSONResult(Result{
+			Kind:    SOLVE_RESULT,
+			Title:   title,
+			Part:    partFromTitle(title),
+			Answer:  answerStr,
+			Runtime: timeTaken,
+		})
+		return
+	}
+
+	// Pretty info
+	prettyAnswer := fmt.Sprintf("Answer : %v\n", answerStr)
+	prettyTime := fmt.Sprintf("Runtime: %v", timeTaken)
+
+	fmt.Println(renderBox(title, prettyAnswer+prettyTime, puzzleSolveColor))
+}
+
+// Renders the title above a box containing the info, both in the given color
+func renderBox(title, info string, color lipgloss.Color) string {
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(puzzleSolveColor).
+		Background(color).
 		Padding(0, 1).Margin(1, 0, 0).
 		AlignHorizontal(lipgloss.Center)
 
 	borderStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(puzzleSolveColor).
-		Padding(0, 2).
-		MaxWidth(18)
-
-	// Pretty info
-	prettyAnswer := fmt.Sprintf("Answer : %v\n", answerStr)
-	prettyTime := fmt.Sprintf("Runtime: %v", timeTaken)
+		BorderForeground(color).
+		Padding(0, 2)
 
 	// Render the answer inside the border
-	wrappedInfo := borderStyle.Render(prettyAnswer + prettyTime)
+	wrappedInfo := borderStyle.Render(info)
 
 	// Render the title
 	titleBox := titleStyle.Width(lipgloss.Width(wrappedInfo)).Render(title)
 
 	// Combine the title and wrapped answer
-	output := lipgloss.JoinVertical(lipgloss.Top, titleBox, wrappedInfo)
-
-	// Display the output
-	fmt.Println(output)
+	return lipgloss.JoinVertical(lipgloss.Top, titleBox, wrappedInfo)
 }
 
 // GetInputAsByteArray will return the user's puzzle input, as determined by the file's working directory, as an array of bytes.
+// A local input.txt next to the solution is used before the cache or the site, and -input or AOCGO_INPUT can point at another file.
+// If the AOC_OFFLINE environment variable is set, the input will only be loaded from the local cache.
 func GetInputAsByteArray() []byte {
-	year, day, err := utils.GetYearAndDayFromCWD()
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	return getData(year, day)
+	return mustLoadInput(InputBytes)
 }
 
 // GetInputAsString will return the user's puzzle input, as determined by the file's working directory, as a single string.
@@ -151,34 +174,240 @@ func GetInputAsLineArray() []string {
 
 // GetInputAsCharMatrix will return the user's puzzle input, as determined by the file's working directory, as a 2D matrix, split on newlines and then by every character
 func GetInputAsCharMatrix() [][]string {
-	var out [][]string
-	for _, line := range GetInputAsLineArray() {
-		out = append(out, strings.Split(line, ""))
-	}
+	return toCharMatrix(GetInputAsLineArray())
+}
 
-	return out
+// GetInputAsIntArray will return the user's puzzle input, as determined by the file's working directory, as an array of ints, one per line.
+func GetInputAsIntArray() []int {
+	return mustLoadInput(InputInts)
 }
 
-func getData(year int, day int) []byte {
-	userToken, err := session.GetSessionToken(false)
+// GetInputAsCommaSeparatedInts will return the user's puzzle input, as determined by the file's working directory, as an array of ints, split on commas.
+func GetInputAsCommaSeparatedInts() []int {
+	return mustLoadInput(InputCommaSeparatedInts)
+}
+
+// GetInputAsRuneMatrix will return the user's puzzle input, as determined by the file's working directory, as a 2D grid of runes, split on newlines.
+func GetInputAsRuneMatrix() [][]rune {
+	return mustLoadInput(InputRuneMatrix)
+}
+
+// GetInputAsBlocks will return the user's puzzle input, as determined by the file's working directory, as groups of lines that were separated by blank lines.
+func GetInputAsBlocks() [][]string {
+	return mustLoadInput(InputBlocks)
+}
+
+// Loads the input for the puzzle in the working directory, exiting if anything goes wrong
+func mustLoadInput[T any](load func(year, day int) (T, error)) T {
+	year, day, err := getYearAndDay()
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	_, err = resources.NewUser(userToken)
+	input, err := load(year, day)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	err = cache.StartupDBM(userToken)
+	return input
+}
+
+// InputBytes will return the user's puzzle input for a given year and day as an array of bytes.
+// Unlike GetInputAsByteArray, any problems are returned to the caller instead of exiting.
+func InputBytes(year, day int) ([]byte, error) {
+	if err := utils.CheckDay(year, day); err != nil {
+		return nil, err
+	}
+
+	return getData(year, day)
+}
+
+// InputString will return the user's puzzle input for a given year and day as a single string.
+func InputString(year, day int) (string, error) {
+	input, err := InputBytes(year, day)
+	return string(input), err
+}
+
+// InputLines will return the user's puzzle input for a given year and day as an array of strings, split on newline.
+func InputLines(year, day int) ([]string, error) {
+	input, err := InputString(year, day)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(input, "\n"), nil
+}
+
+// InputMatrix will return the user's puzzle input for a given year and day as a 2D matrix, split on newlines and then by every character.
+func InputMatrix(year, day int) ([][]string, error) {
+	lines, err := InputLines(year, day)
+	if err != nil {
+		return nil, err
+	}
+	return toCharMatrix(lines), nil
+}
+
+// InputInts will return the user's puzzle input for a given year and day as an array of ints, one per line.
+// Returns an error if any line isn't a number.
+func InputInts(year, day int) ([]int, error) {
+	input, err := InputString(year, day)
+	if err != nil {
+		return nil, err
+	}
+	return parseInts(trimInput(input), "\n")
+}
+
+// InputCommaSeparatedInts will return the user's puzzle input for a given year and day as an array of ints, split on commas.
+// Returns an error if any value isn't a number.
+func InputCommaSeparatedInts(year, day int) ([]int, error) {
+	input, err := InputString(year, day)
+	if err != nil {
+		return nil, err
+	}
+	return parseInts(trimInput(input), ",")
+}
+
+// InputRuneMatrix will return the user's puzzle input for a given year and day as a 2D grid of runes, split on newlines.
+func InputRuneMatrix(year, day int) ([][]rune, error) {
+	input, err := InputString(year, day)
+	if err != nil {
+		return nil, err
+	}
+	return toRuneMatrix(trimInput(input)), nil
+}
+
+// InputBlocks will return the user's puzzle input for a given year and day as groups of lines that were separated by blank lines.
+func InputBlocks(year, day int) ([][]string, error) {
+	input, err := InputString(year, day)
+	if err != nil {
+		return nil, err
+	}
+	return toBlocks(trimInput(input)), nil
+}
+
+// Example is an example input from a puzzle's page, along with the answer the page says it should produce
+type Example = resources.Example
+
+// GetExamples will return the examples from a part of the puzzle's page, as determined by the file's working directory.
+// Part two's examples are only available once part one has been solved.
+func GetExamples(part int) []Example {
+	year, day, err := getYearAndDay()
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	puzzle := resources.LoadOrCreatePuzzle(year, day, userToken)
-	input, err := puzzle.GetUserInput()
+	examples, err := Examples(year, day, part)
 	if err != nil {
 		log.Fatal(err)
 	}
-	return input
+
+	return examples
+}
+
+// Examples will return the examples from a part of a given year and day's puzzle page.
+// Unlike GetExamples, any problems are returned to the caller instead of exiting.
+func Examples(year, day, part int) ([]Example, error) {
+	if part != 1 && part != 2 {
+		return nil, fmt.Errorf("Part %v is not a valid puzzle part", part)
+	}
+
+	var examples []Example
+	err := usePuzzle(year, day, func(puzzle *resources.Puzzle) error {
+		if err := puzzle.LoadExamples(); err != nil {
+			return err
+		}
+		examples = puzzle.GetExamples(part)
+		return nil
+	})
+
+	return examples, err
+}
+
+func toCharMatrix(lines []string) [][]string {
+	var out [][]string
+	for _, line := range lines {
+		out = append(out, strings.Split(line, ""))
+	}
+
+	return out
+}
+
+// Removes the trailing newline from input, so it doesn't become an empty line or value
+func trimInput(input string) string {
+	return strings.TrimRight(input, "\r\n")
+}
+
+// Parses every value between separators as an int, ignoring surrounding whitespace
+func parseInts(input, sep string) ([]int, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, nil
+	}
+
+	var out []int
+	for i, value := range strings.Split(input, sep) {
+		num, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("Value %v (%q) is not a number", i+1, value)
+		}
+		out = append(out, num)
+	}
+
+	return out, nil
+}
+
+func toRuneMatrix(input string) [][]rune {
+	var out [][]rune
+	for _, line := range strings.Split(input, "\n") {
+		out = append(out, []rune(strings.TrimRight(line, "\r")))
+	}
+
+	return out
+}
+
+func toBlocks(input string) [][]string {
+	var out [][]string
+	for _, block := range strings.Split(strings.ReplaceAll(input, "\r\n", "\n"), "\n\n") {
+		out = append(out, strings.Split(block, "\n"))
+	}
+
+	return out
+}
+
+func getData(year int, day int) ([]byte, error) {
+	if input, ok, err := loadLocalInput(year, day); ok || err != nil {
+		return input, err
+	}
+
+	var input []byte
+	err := usePuzzle(year, day, func(puzzle *resources.Puzzle) error {
+		var err error
+		input, err = puzzle.GetUserInput()
+		return err
+	})
+
+	return input, err
+}
+
+// Opens the user's cache and loads a puzzle, which can only be used until fn returns
+func usePuzzle(year, day int, fn func(*resources.Puzzle) error) error {
+	userToken, err := session.GetSessionToken(false)
+	if err != nil {
+		return err
+	}
+	userToken = strings.TrimSpace(userToken)
+
+	err = cache.StartupDBM(userToken)
+	if err != nil {
+		return err
+	}
+	defer cache.ShutdownDBM()
+
+	// Puzzles only need the token, so the user (and their display name) is never loaded
+	api.InitClient(userToken)
+
+	puzzle, err := resources.LoadOrCreatePuzzle(year, day, userToken)
+	if err != nil {
+		return err
+	}
+
+	return fn(puzzle)
 }
@@ -0,0 +1,58 @@
+package aocgo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseInts(t *testing.T) {
+	ints, err := parseInts(trimInput("1\n-22\n 333 \n"), "\n")
+	if err != nil || !reflect.DeepEqual(ints, []int{1, -22, 333}) {
+		t.Errorf("Expected [1 -22 333], got %v (err: %v)", ints, err)
+	}
+
+	ints, err = parseInts(trimInput("3,4, 5\n"), ",")
+	if err != nil || !reflect.DeepEqual(ints, []int{3, 4, 5}) {
+		t.Errorf("Expected [3 4 5], got %v (err: %v)", ints, err)
+	}
+
+	if ints, err = parseInts("", "\n"); err != nil || len(ints) != 0 {
+		t.Errorf("Expected no ints from empty input, got %v (err: %v)", ints, err)
+	}
+
+	if _, err = parseInts("1\nabc\n3", "\n"); err == nil {
+		t.Errorf("Expected an error for a value that isn't a number")
+	}
+}
+
+func TestToRuneMatrix(t *testing.T) {
+	matrix := toRuneMatrix(trimInput("#.\r\n.é\n"))
+	expected := [][]rune{{'#', '.'}, {'.', 'é'}}
+	if !reflect.DeepEqual(matrix, expected) {
+		t.Errorf("Expected %q, got %q", expected, matrix)
+	}
+}
+
+func TestToBlocks(t *testing.T) {
+	blocks := toBlocks(trimInput("1000\n2000\n\n3000\n\n4000\n5000\n"))
+	expected := [][]string{{"1000", "2000"}, {"3000"}, {"4000", "5000"}}
+	if !reflect.DeepEqual(blocks, expected) {
+		t.Errorf("Expected %v, got %v", expected, blocks)
+	}
+}
+
+func TestConvertInput(t *testing.T) {
+	ints, err := convertInput[[]int]([]byte("4\n5\n"))
+	if err != nil || !reflect.DeepEqual(ints, []int{4, 5}) {
+		t.Errorf("Expected [4 5], got %v (err: %v)", ints, err)
+	}
+
+	if _, err := convertInput[[]int]([]byte("4\nfive\n")); err == nil {
+		t.Errorf("Expected an error for a line that isn't a number")
+	}
+
+	runes, err := convertInput[[][]rune]([]byte("ab\ncd\n"))
+	if err != nil || !reflect.DeepEqual(runes, [][]rune{{'a', 'b'}, {'c', 'd'}}) {
+		t.Errorf("Expected a 2x2 rune matrix, got %q (err: %v)", runes, err)
+	}
+}
@@ -0,0 +1,192 @@
+package aocgo
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.dalton.dog/aocgo/internal/cache"
+	"go.dalton.dog/aocgo/internal/resources"
+	"go.dalton.dog/aocgo/internal/session"
+
+	"github.com/charmbracelet/log"
+)
+
+// Environment variables that override how long benchmarks run for.
+// If either is set, RunSolve benchmarks the solver instead of running it once.
+const (
+	BENCH_RUNS_ENV = "AOCGO_BENCH_RUNS"
+	BENCH_TIME_ENV = "AOCGO_BENCH_TIME"
+)
+
+// Defaults for benchmarks that don't set their own limits
+const (
+	DEFAULT_BENCH_RUNS = 100
+	DEFAULT_BENCH_TIME = 10 * time.Second
+)
+
+// BenchmarkStats summarizes the runtimes and allocations from benchmarking a solver
+type BenchmarkStats = resources.BenchmarkStats
+
+// BenchmarkOptions controls how many times a solver is run when benchmarking.
+// The benchmark stops at whichever limit is hit first, but the solver is always run at least once.
+// If neither limit is set, the solver is only run once.
+type BenchmarkOptions struct {
+	// Maximum number of times to run the solver. No limit if 0.
+	Runs int
+
+	// Maximum total time to spend running the solver. No limit if 0.
+	Budget time.Duration
+}
+
+// Benchmark will run the solver repeatedly and print the runtime and allocation stats.
+// It runs DEFAULT_BENCH_RUNS times or for DEFAULT_BENCH_TIME, unless AOCGO_BENCH_RUNS or AOCGO_BENCH_TIME are set.
+// Results are stored for the puzzle, as determined by the file's working directory, and the part in the title,
+// so the median runtime can be compared against the last benchmark.
+// If AOCGO_OUTPUT is set to "json", the result is printed as a single line of JSON instead.
+// If AOC_PART is set to a different part than the one in the title, the solver isn't run and empty stats are returned.
+func Benchmark[In InputData, Out AnswerData](title string, solver Solver[In, Out], inputData In) BenchmarkStats {
+	return BenchmarkWithOptions(title, solver, inputData, benchmarkOptionsFromEnv())
+}
+
+// BenchmarkWithOptions works like Benchmark, but with limits chosen by the caller instead of the environment
+func BenchmarkWithOptions[In InputData, Out AnswerData](title string, solver Solver[In, Out], inputData In, opts BenchmarkOptions) BenchmarkStats {
+	part := partFromTitle(title)
+	if !partRequested(part) {
+		return BenchmarkStats{}
+	}
+
+	answer, stats := runBenchmark(solver, inputData, opts)
+	answerStr := fmt.Sprintf("%v", answer)
+
+	previous, hasPrevious := storeBenchmark(part, stats)
+
+	if jsonOutput() {
+		result := Result{
+			Kind:      BENCH_RESULT,
+			Title:     title,
+			Part:      part,
+			Answer:    answerStr,
+			Runtime:   stats.Median,
+			Benchmark: &stats,
+		}
+		if hasPrevious {
+			result.Previous = &previous
+		}
+
+		printJSONResult(result)
+		return stats
+	}
+
+	info := fmt.Sprintf("Answer : %v\nRuns   : %v\nMin    : %v\nMedian : %v\nMean   : %v\np95    : %v\nAllocs : %v/run\nMemory : %v/run",
+		answerStr, stats.Runs,
+		resources.FormatRuntime(stats.Min), resources.FormatRuntime(stats.Median),
+		resources.FormatRuntime(stats.Mean), resources.FormatRuntime(stats.P95),
+		stats.AllocsPerRun, resources.FormatBytes(stats.BytesPerRun))
+	if hasPrevious {
+		info += fmt.Sprintf("\nChange : %v (was %v)", resources.FormatMedianChange(stats.MedianChange(previous)), resources.FormatRuntime(previous.Median))
+	}
+
+	fmt.Println(renderBox(title, info, puzzleSolveColor))
+	return stats
+}
+
+// Runs the solver until one of the limits is hit, returning its last answer
+func runBenchmark[In InputData, Out AnswerData](solver Solver[In, Out], inputData In, opts BenchmarkOptions) (Out, BenchmarkStats) {
+	var answer Out
+	var runtimes []time.Duration
+
+	// Collect garbage from before the benchmark so it isn't counted against the solver
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	for {
+		runStart := time.Now()
+		answer = solver(inputData)
+		runtimes = append(runtimes, time.Since(runStart))
+
+		if opts.Runs > 0 && len(runtimes) >= opts.Runs {
+			break
+		}
+		if opts.Budget > 0 && time.Since(start) >= opts.Budget {
+			break
+		}
+		if opts.Runs <= 0 && opts.Budget <= 0 {
+			break
+		}
+	}
+
+	runtime.ReadMemStats(&after)
+
+	return answer, resources.NewBenchmarkStats(runtimes, after.Mallocs-before.Mallocs, after.TotalAlloc-before.TotalAlloc)
+}
+
+// Returns true if RunSolve should benchmark instead of running the solver once
+func benchmarkRequested() bool {
+	return os.Getenv(BENCH_RUNS_ENV) != "" || os.Getenv(BENCH_TIME_ENV) != ""
+}
+
+// Reads the benchmark limits from the environment, using the defaults for anything not set.
+// Setting only one of them removes the other limit.
+func benchmarkOptionsFromEnv() BenchmarkOptions {
+	runsEnv := strings.TrimSpace(os.Getenv(BENCH_RUNS_ENV))
+	timeEnv := strings.TrimSpace(os.Getenv(BENCH_TIME_ENV))
+
+	if runsEnv == "" && timeEnv == "" {
+		return BenchmarkOptions{Runs: DEFAULT_BENCH_RUNS, Budget: DEFAULT_BENCH_TIME}
+	}
+
+	var opts BenchmarkOptions
+	if runsEnv != "" {
+		runs, err := strconv.Atoi(runsEnv)
+		if err != nil || runs < 0 {
+			log.Warn("Invalid number of benchmark runs, using the default.", BENCH_RUNS_ENV, runsEnv)
+			runs = DEFAULT_BENCH_RUNS
+		}
+		opts.Runs = runs
+	}
+
+	if timeEnv != "" {
+		budget, err := time.ParseDuration(timeEnv)
+		if err != nil || budget < 0 {
+			log.Warn("Invalid benchmark time, using the default.", BENCH_TIME_ENV, timeEnv)
+			budget = DEFAULT_BENCH_TIME
+		}
+		opts.Budget = budget
+	}
+
+	return opts
+}
+
+// Stores a benchmark for the puzzle in the working directory, returning the one before it.
+// Benchmarks are only for comparing runs, so any problems storing them are just warnings.
+func storeBenchmark(part int, stats BenchmarkStats) (BenchmarkStats, bool) {
+	year, day, err := getYearAndDay()
+	if err != nil {
+		log.Warn("Unable to find the puzzle, benchmark won't be stored.", "err", err)
+		return BenchmarkStats{}, false
+	}
+
+	userToken, err := session.GetSessionToken(false)
+	if err != nil {
+		log.Warn("Unable to load a session token, benchmark won't be stored.", "err", err)
+		return BenchmarkStats{}, false
+	}
+
+	if err := cache.StartupDBM(strings.TrimSpace(userToken)); err != nil {
+		log.Warn("Unable to open the cache, benchmark won't be stored.", "err", err)
+		return BenchmarkStats{}, false
+	}
+	defer cache.ShutdownDBM()
+
+	history := resources.LoadBenchmarkHistory(year, day, part)
+	previous, hasPrevious := history.Latest()
+	history.AddBenchmark(stats)
+
+	return previous, hasPrevious
+}
@@ -0,0 +1,62 @@
+package aocgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRunBenchmark(t *testing.T) {
+	calls := 0
+	solver := func(input string) int {
+		calls++
+		return len(input)
+	}
+
+	answer, stats := runBenchmark(solver, "abc", BenchmarkOptions{Runs: 25})
+	if answer != 3 {
+		t.Errorf("Expected an answer of 3, got %v", answer)
+	}
+	if calls != 25 || stats.Runs != 25 {
+		t.Errorf("Expected 25 runs, got %v calls and %v runs in the stats", calls, stats.Runs)
+	}
+
+	calls = 0
+	_, stats = runBenchmark(solver, "abc", BenchmarkOptions{})
+	if calls != 1 || stats.Runs != 1 {
+		t.Errorf("Expected a single run without any limits, got %v calls", calls)
+	}
+
+	slow := func(input string) int {
+		time.Sleep(10 * time.Millisecond)
+		return 0
+	}
+
+	_, stats = runBenchmark(slow, "", BenchmarkOptions{Runs: 1000, Budget: 50 * time.Millisecond})
+	if stats.Runs >= 1000 || stats.Runs < 1 {
+		t.Errorf("Expected the time budget to stop the benchmark, got %v runs", stats.Runs)
+	}
+}
+
+func TestBenchmarkOptionsFromEnv(t *testing.T) {
+	t.Setenv(BENCH_RUNS_ENV, "")
+	t.Setenv(BENCH_TIME_ENV, "")
+	if opts := benchmarkOptionsFromEnv(); opts.Runs != DEFAULT_BENCH_RUNS || opts.Budget != DEFAULT_BENCH_TIME {
+		t.Errorf("Expected the default options, got %+v", opts)
+	}
+	if benchmarkRequested() {
+		t.Errorf("Expected no benchmark to be requested")
+	}
+
+	t.Setenv(BENCH_RUNS_ENV, "10")
+	if opts := benchmarkOptionsFromEnv(); opts.Runs != 10 || opts.Budget != 0 {
+		t.Errorf("Expected only a run limit, got %+v", opts)
+	}
+
+	t.Setenv(BENCH_TIME_ENV, "2s")
+	if opts := benchmarkOptionsFromEnv(); opts.Runs != 10 || opts.Budget != 2*time.Second {
+		t.Errorf("Expected both limits, got %+v", opts)
+	}
+	if !benchmarkRequested() {
+		t.Errorf("Expected a benchmark to be requested")
+	}
+}
@@ -1,13 +1,15 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
-	"io"
 	"os"
-	"path/filepath"
+	"strings"
+	"time"
 
 	"go.dalton.dog/aocgo/internal/api"
 	"go.dalton.dog/aocgo/internal/cache"
+	"go.dalton.dog/aocgo/internal/config"
 	"go.dalton.dog/aocgo/internal/resources"
 	"go.dalton.dog/aocgo/internal/session"
 	"go.dalton.dog/aocgo/internal/styles"
@@ -66,39 +68,50 @@ func main() {
 // }
 
 // Leaderboard obtains and displays Leaderboard information for a specific year or day
-// Command: `aocli leaderboard -y yyyy [-d dd]`
+// Command: `aocli leaderboard -y yyyy [-d dd] [--private id]`
 // Params:
 //
-//	(Req) year - 2 or 4 digit year (16 or 2016)
-//	(Opt) day  - 1 or 2 digit day (1, 01, 21)
-func Leaderboard(yearIn, dayIn string) {
+//	(Req) year    - 2 or 4 digit year (16 or 2016)
+//	(Opt) day     - 1 or 2 digit day (1, 01, 21)
+//	(Opt) private - ID of a private leaderboard to display instead of the global one
+func Leaderboard(yearIn, dayIn, privateID string) {
 	var year int
 	var day int
 	var err error
 	var lb resources.ViewableLB
 
 	if yearIn == "0" {
-		year, day, err := utils.GetYearAndDayFromCWD()
+		year, day, err = utils.GetYearAndDayFromCWD()
 		if err != nil {
-			log.Fatal("Error loading leaderboard based on current directory!", "err", err)
+			if privateID == "" {
+				log.Fatal("Error loading leaderboard based on current directory!", "err", err)
+			}
+			year, _ = utils.GetCurrentMaxYearAndDay()
+			day = 0
 		}
-		lb = resources.LoadOrCreateLeaderboard(year, day)
 	} else {
 		year, err = utils.ParseYear(yearIn)
 		if err != nil {
 			log.Fatal("Error parsing year!", "err", err)
 		}
-	}
 
-	if lb == nil {
 		if dayIn != "0" {
 			day, err = utils.ParseDay(dayIn)
 			if err != nil {
 				log.Fatal("Error parsing day from args.", "err", err)
 			}
-			lb = resources.LoadOrCreateLeaderboard(year, day)
-		} else {
-			lb = resources.LoadOrCreateLeaderboard(year, 0)
+		}
+	}
+
+	if privateID != "" {
+		lb, err = resources.LoadOrCreatePrivateLeaderboard(year, day, privateID)
+		if err != nil {
+			log.Fatal("Unable to load private leaderboard!", "err", err)
+		}
+	} else {
+		lb, err = resources.LoadOrCreateLeaderboard(year, day)
+		if err != nil {
+			log.Fatal("Unable to load leaderboard!", "err", err)
 		}
 	}
 
@@ -127,7 +140,10 @@ func Health() {
 
 	// user, err := resources.NewUser(sessionToken)
 
-	resources.LoadOrCreatePuzzle(2016, 1, sessionToken)
+	_, err = resources.LoadOrCreatePuzzle(2016, 1, sessionToken)
+	if err != nil {
+		log.Fatal("Test failed! Couldn't load a puzzle.", "err", err)
+	}
 
 	log.Info("Session token appears to be valid, happy solving!")
 }
@@ -136,13 +152,20 @@ func Health() {
 
 // Submit will submit the answer provided.
 // If date arguments aren't provided, they will be parsed from the current directory.
-// Command: `aocli submit <answer> [-y yyyy -d dd --part {1|2}]`
+// Command: `aocli submit <answer> [-y yyyy -d dd --part {1|2} --wait]`
 // Params:
 //
 //	(Req) answer - Answer to submit to the server
 //	(Opt) year   - 2 or 4 digit year (16 or 2016)
 //	(Opt) day    - 1 or 2 digit day (1, 01, 21)
-func Submit(user *resources.User, yearIn, dayIn, answer string, partIn int) {
+//	(Opt) wait   - Wait out any lockout with a countdown, then submit
+func Submit(user *resources.User, yearIn, dayIn, answer string, partIn int, waitForLockout bool) {
+	year, day := getSubmitYearAndDay(yearIn, dayIn)
+	submitAnswer(user, year, day, answer, partIn, waitForLockout)
+}
+
+// Parses the year and day to submit to, with any that aren't provided parsed from the current directory
+func getSubmitYearAndDay(yearIn, dayIn string) (int, int) {
 	var year, day int
 	var err error
 
@@ -176,7 +199,15 @@ func Submit(user *resources.User, yearIn, dayIn, answer string, partIn int) {
 
 	}
 
-	puzzle := resources.LoadOrCreatePuzzle(year, day, user.SessionTok)
+	return year, day
+}
+
+// Submits an answer to a puzzle and prints the result
+func submitAnswer(user *resources.User, year, day int, answer string, partIn int, waitForLockout bool) {
+	puzzle, err := resources.LoadOrCreatePuzzle(year, day, user.SessionTok)
+	if err != nil {
+		log.Fatal("Unable to load puzzle.", "err", err)
+	}
 
 	var part int
 	if partIn < 0 || partIn > 2 {
@@ -186,22 +217,137 @@ func Submit(user *resources.User, yearIn, dayIn, answer string, partIn int) {
 		part = partIn
 	}
 
-	answerResp, message := puzzle.SubmitAnswer(answer, part)
+	if ProjectConfig.Submit.Confirm && !confirmSubmit(year, day, part, answer) {
+		log.Info("Answer not submitted.")
+		return
+	}
+
+	result, err := puzzle.SubmitAnswer(answer, part)
+	if err != nil {
+		log.Fatal("Unable to submit answer.", "err", err)
+	}
+
+	// The site's wait times are rounded, so give it an extra second before trying again
+	for waitForLockout && result.Outcome == resources.OutcomeRateLimited && result.Wait > 0 {
+		if !RunCountdown(time.Now().Add(result.Wait+time.Second), "Locked out, submitting answer in") {
+			log.Info("Answer not submitted.")
+			return
+		}
+
+		result, err = puzzle.SubmitAnswer(answer, part)
+		if err != nil {
+			log.Fatal("Unable to submit answer.", "err", err)
+		}
+	}
 
-	if answerResp == resources.CorrectAnswer {
-		fmt.Println(styles.CorrectAnswerStyle.Render("Correct answer!"))
-		fmt.Println(styles.CorrectAnswerStyle.Render(message))
+	if result.Outcome == resources.OutcomeCorrect {
 		user.NumStars++
-	} else if answerResp == resources.IncorrectAnswer {
-		fmt.Println(styles.IncorrectAnswerStyle.Render("Incorrect answer!"))
-		fmt.Println(styles.IncorrectAnswerStyle.Render(message))
-	} else if answerResp == resources.WarningAnswer {
-		fmt.Println(styles.WarningAnswerStyle.Render("Answer not submitted!"))
-		fmt.Println(styles.WarningAnswerStyle.Render(message))
-	} else if answerResp == resources.NeutralAnswer {
-		fmt.Println(styles.NeutralAnswerStyle.Render("Answer not submitted!"))
-		fmt.Println(styles.NeutralAnswerStyle.Render(message))
 	}
+
+	printSubmissionResult(result)
+}
+
+// Asks the user whether the answer should be submitted
+func confirmSubmit(year, day, part int, answer string) bool {
+	partStr := "the next part"
+	if part != 0 {
+		partStr = fmt.Sprintf("part %v", part)
+	}
+
+	fmt.Print(styles.WarningAnswerStyle.Render(fmt.Sprintf("Submit %v to %v of %v day %v? [y/N] ", answer, partStr, year, day)))
+
+	response, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	response = strings.ToLower(strings.TrimSpace(response))
+	return response == "y" || response == "yes"
+}
+
+// Prints a heading for the submission's outcome, followed by the message explaining it
+func printSubmissionResult(result resources.SubmissionResult) {
+	var heading string
+	style := styles.WarningAnswerStyle
+
+	switch result.Outcome {
+	case resources.OutcomeCorrect:
+		heading, style = "Correct answer!", styles.CorrectAnswerStyle
+	case resources.OutcomeTooHigh:
+		heading, style = "Incorrect answer, it's too high!", styles.IncorrectAnswerStyle
+	case resources.OutcomeTooLow:
+		heading, style = "Incorrect answer, it's too low!", styles.IncorrectAnswerStyle
+	case resources.OutcomeWrong:
+		heading, style = "Incorrect answer!", styles.IncorrectAnswerStyle
+	case resources.OutcomeRateLimited:
+		heading = "Submitting too quickly!"
+		if result.Wait > 0 {
+			heading = fmt.Sprintf("Submitting too quickly! Try again in %v.", result.Wait.Round(time.Second))
+		}
+	case resources.OutcomeWrongLevel:
+		heading, style = "Wrong level! That part may already be solved, try running `reload`.", styles.NeutralAnswerStyle
+	case resources.OutcomeAlreadySolved:
+		heading, style = "Already solved!", styles.NeutralAnswerStyle
+	case resources.OutcomeNotLoggedIn:
+		heading, style = "Not logged in! Your session token may have expired.", styles.IncorrectAnswerStyle
+	case resources.OutcomePuzzleLocked:
+		heading = "This puzzle hasn't unlocked yet!"
+	case resources.OutcomeDuplicate, resources.OutcomeOutOfBounds:
+		heading = "Answer not submitted!"
+	default:
+		heading = "Unrecognized response from the site."
+	}
+
+	if !result.Submitted && result.Outcome != resources.OutcomeDuplicate && result.Outcome != resources.OutcomeOutOfBounds {
+		heading += " (answer not submitted)"
+	}
+
+	fmt.Println(style.Render(heading))
+	fmt.Println(style.Render(result.Message))
+}
+
+// History shows every answer submitted for a puzzle, or a summary of a year's submissions.
+// If date arguments aren't provided, they will be parsed from the current directory.
+// Command: `aocli history [-y yyyy -d dd]`
+// Params:
+//
+//	(Opt) year - 2 or 4 digit year (16 or 2016)
+//	(Opt) day  - 1 or 2 digit day (1, 01, 21). Leave out with a year for the year's summary.
+func History(user *resources.User, yearIn, dayIn string) {
+	var year int
+	var day int
+	var err error
+
+	if yearIn != "0" && dayIn == "0" {
+		year, err = utils.ParseYear(yearIn)
+		if err != nil {
+			log.Fatal("Unable to parse year from args.", "err", err)
+		}
+
+		resources.NewLeaderboardViewport(resources.GetYearHistoryContent(year), resources.GetYearHistoryTitle(year))
+		return
+	}
+
+	if yearIn == "0" || dayIn == "0" {
+		year, day, err = utils.GetYearAndDayFromCWD()
+		if err != nil {
+			log.Fatal("Unable to parse year/day from current directory.", "err", err)
+		}
+	} else {
+		year, err = utils.ParseYear(yearIn)
+		if err != nil {
+			log.Fatal("Unable to parse year from args.", "err", err)
+		}
+
+		day, err = utils.ParseDay(dayIn)
+		if err != nil {
+			log.Fatal("Unable to parse day from args.", "err", err)
+		}
+	}
+
+	puzzle := resources.LoadCachedPuzzle(year, day)
+	if puzzle == nil {
+		log.Info("No answers have been submitted for that puzzle.")
+		return
+	}
+
+	resources.NewLeaderboardViewport(puzzle.GetHistoryContent(), puzzle.GetHistoryTitle())
 }
 
 // Reload will force reload the puzzle data for a specific day
@@ -233,8 +379,13 @@ func Reload(user *resources.User, yearIn, dayIn string) {
 		}
 	}
 
-	puzzle := resources.LoadOrCreatePuzzle(year, day, user.GetToken())
-	puzzle.ReloadPuzzleData()
+	puzzle, err := resources.LoadOrCreatePuzzle(year, day, user.GetToken())
+	if err != nil {
+		log.Fatal("Unable to load puzzle.", "err", err)
+	}
+	if err := puzzle.ReloadPuzzleData(); err != nil {
+		log.Fatal("Unable to reload puzzle data.", "err", err)
+	}
 }
 
 // User will print out a table visualization of the user's star progress.
@@ -275,17 +426,49 @@ func View(user *resources.User, yearIn, dayIn string) {
 		}
 	}
 
-	puzzle := resources.LoadOrCreatePuzzle(year, day, user.GetToken())
+	puzzle, err := resources.LoadOrCreatePuzzle(year, day, user.GetToken())
+	if err != nil {
+		log.Fatal("Unable to load puzzle.", "err", err)
+	}
 	puzzle.Display()
 }
 
-// Get obtains input data for a specific day, outputting it to the current directory `input.txt`.
+// Calendar shows a year's calendar, with the user's stars and the art they've unlocked.
+// The arrow keys move between days, and enter opens a day's puzzle.
+// Command: `aocli calendar [-y yyyy]`
+// Params:
+//
+//	(Opt) year - 2 or 4 digit year (16 or 2016). Defaults to the year of the current directory, then the latest year.
+func Calendar(user *resources.User, yearIn string) {
+	var year int
+	var err error
+
+	if yearIn == "0" {
+		year, _, err = utils.GetYearAndDayFromCWD()
+		if err != nil {
+			year, _ = utils.GetCurrentMaxYearAndDay()
+		}
+	} else {
+		year, err = utils.ParseYear(yearIn)
+		if err != nil {
+			log.Fatal("Error parsing year!", "err", err)
+		}
+	}
+
+	calendar, err := resources.LoadOrCreateCalendar(year)
+	if err != nil {
+		log.Fatal("Unable to load calendar.", "err", err)
+	}
+	resources.NewCalendarViewport(calendar, user.GetToken())
+}
+
+// Get obtains input data for a specific day, outputting it to the project's input file in the current directory, `input.txt` by default.
 // Command: `aocli get [-y yyyy -d dd -o output_name.txt]`
 // Params:
 //
 //	(Opt) year - 2 or 4 digit year (16 or 2016)
 //	(Opt) day  - 1 or 2 digit day (1, 01, 21)
-//	(Opt) filename  - overrides "input.txt" name if it's provided
+//	(Opt) filename  - overrides the input file name if it's provided
 func Get(user *resources.User, yearIn, dayIn string, filename string) {
 	var year int
 	var day int
@@ -308,8 +491,21 @@ func Get(user *resources.User, yearIn, dayIn string, filename string) {
 		}
 	}
 
-	puzzle := resources.LoadOrCreatePuzzle(year, day, user.GetToken())
-	userInput, _ := puzzle.GetUserInput()
+	puzzle, err := resources.LoadOrCreatePuzzle(year, day, user.GetToken())
+	if err != nil {
+		log.Fatal("Unable to load puzzle.", "err", err)
+	}
+	userInput, err := puzzle.GetUserInput()
+	if err != nil {
+		log.Fatal("Unable to load puzzle input.", "err", err)
+	}
+
+	if filename == "" {
+		filename = utils.FormatLayout(ProjectConfig.Input, year, day)
+	}
+	if filename == "" {
+		filename = config.DEFAULT_INPUT_FILENAME
+	}
 
 	out, _ := os.Create(filename)
 	defer out.Close()
@@ -318,47 +514,67 @@ func Get(user *resources.User, yearIn, dayIn string, filename string) {
 	log.Infof("Input saved to %v!", filename)
 }
 
-// New will make a copy of the given base file into the given out file in a ./yearIn/dayIn/ directory.
-// Command `aocli new [-y yyyy -d dd -b base.go -o main.go]`
-func New(yearIn, dayIn, baseFile, outFile string) {
-	if yearIn == "0" {
-		log.Fatal("Must provide a year with the -y option.")
-	}
-
-	if dayIn == "0" {
-		log.Fatal("Must provide a day with the -d option.")
-	}
+// Examples writes the example inputs from the puzzle's page to example1.txt and example2.txt in the current directory.
+// If a part has more than one example, the rest are written to files like example1-2.txt.
+// Command: `aocli examples [-y yyyy -d dd]`
+// Params:
+//
+//	(Opt) year - 2 or 4 digit year (16 or 2016)
+//	(Opt) day  - 1 or 2 digit day (1, 01, 21)
+func Examples(user *resources.User, yearIn, dayIn string) {
+	var year int
+	var day int
+	var err error
 
-	// Build the directory path, e.g. "./2025/15"
-	dirPath := filepath.Join(".", yearIn, dayIn)
+	if yearIn == "0" || dayIn == "0" {
+		year, day, err = utils.GetYearAndDayFromCWD()
+		if err != nil {
+			log.Fatal("Unable to parse year/day from current directory.", "err", err)
+		}
+	} else {
+		year, err = utils.ParseYear(yearIn)
+		if err != nil {
+			log.Fatal("Unable to parse year from args.", "err", err)
+		}
 
-	// Create the directory (with parents, if needed).
-	if err := os.MkdirAll(dirPath, 0755); err != nil {
-		log.Fatalf("Failed to create directory %s: %v", dirPath, err)
+		day, err = utils.ParseDay(dayIn)
+		if err != nil {
+			log.Fatal("Unable to parse day from args.", "err", err)
+		}
 	}
 
-	// Open the source/base file.
-	src, err := os.Open(baseFile)
+	puzzle, err := resources.LoadOrCreatePuzzle(year, day, user.GetToken())
 	if err != nil {
-		log.Fatalf("Failed to open base file %s: %v", baseFile, err)
+		log.Fatal("Unable to load puzzle.", "err", err)
 	}
-	defer src.Close()
-
-	// Create the destination file in the new directory.
-	dstPath := filepath.Join(dirPath, outFile)
-	dst, err := os.Create(dstPath)
-	if err != nil {
-		log.Fatalf("Failed to create output file %s: %v", dstPath, err)
+	if err := puzzle.LoadExamples(); err != nil {
+		log.Fatal("Unable to load examples.", "err", err)
 	}
-	defer dst.Close()
 
-	// Copy the entire contents from baseFile to outFile.
-	if _, err := io.Copy(dst, src); err != nil {
-		log.Fatalf("Failed to copy data from %s to %s: %v", baseFile, dstPath, err)
-	}
+	for part := 1; part <= 2; part++ {
+		examples := puzzle.GetExamples(part)
+		if len(examples) == 0 {
+			log.Warnf("No examples found for part %v.", part)
+			continue
+		}
+
+		for i, example := range examples {
+			filename := fmt.Sprintf("example%v.txt", part)
+			if i > 0 {
+				filename = fmt.Sprintf("example%v-%v.txt", part, i+1)
+			}
 
-	log.Infof("Successfully copied %s to %s", baseFile, dstPath)
+			if err := os.WriteFile(filename, []byte(example.Input), 0644); err != nil {
+				log.Fatal("Unable to write example.", "file", filename, "err", err)
+			}
 
+			expected := example.Expected
+			if expected == "" {
+				expected = "unknown"
+			}
+			log.Infof("Part %v example saved to %v! Expected answer: %v", part, filename, expected)
+		}
+	}
 }
 
 // Test does whatever I need to test at the time :)
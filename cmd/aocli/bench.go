@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.dalton.dog/aocgo"
+	"go.dalton.dog/aocgo/internal/cache"
+	"go.dalton.dog/aocgo/internal/resources"
+	"go.dalton.dog/aocgo/internal/styles"
+
+	"github.com/charmbracelet/log"
+)
+
+// Bench will benchmark a solution in the current directory, comparing each part against its last benchmark.
+// Only solutions using aocgo.RunSolve or aocgo.Benchmark can be benchmarked, since the runs happen inside the solution.
+// Command: `aocli bench [-p {1|2} -n runs -t time --cmd "go run ." --input file --history]`
+// Params:
+//
+//	(Opt) part    - Part to benchmark. Passed to the solution in AOC_PART, so only that part's solvers are benchmarked.
+//	(Opt) runs    - Maximum number of times to run each solver. 0 for no limit.
+//	(Opt) budget  - Maximum time to spend running each solver. 0 for no limit, but runs and budget can't both be 0.
+//	(Opt) command - Command that runs the solution. Defaults to AOCLI_RUN_CMD, then the project config, then "go run ."
+//	(Opt) input   - Input file to benchmark the solution against instead of the puzzle's input
+//	(Opt) history - Show the stored benchmarks instead of running new ones
+func Bench(user *resources.User, yearIn, dayIn, command, input string, part, runs int, budget time.Duration, history bool) {
+	year, day := getSubmitYearAndDay(yearIn, dayIn)
+
+	if history {
+		resources.NewLeaderboardViewport(resources.GetBenchmarkContent(year, day), resources.GetBenchmarkTitle(year, day))
+		return
+	}
+
+	if part < 0 || part > 2 {
+		log.Fatal("Part must be 1 or 2.")
+	}
+	if runs < 0 || budget < 0 {
+		log.Fatal("Runs and time can't be negative.")
+	}
+	if runs == 0 && budget == 0 {
+		log.Fatal("Runs and time can't both be 0, the benchmark would never stop.")
+	}
+
+	command = getRunCommand(command)
+
+	env := append(getInputEnv(input),
+		fmt.Sprintf("%v=%v", aocgo.BENCH_RUNS_ENV, runs),
+		fmt.Sprintf("%v=%v", aocgo.BENCH_TIME_ENV, budget),
+	)
+
+	// Solutions using aocgo store their own benchmarks, so the cache can't be held open while they run
+	cache.ShutdownDBM()
+	_, results, _, err := runSolution(command, year, day, part, env...)
+	if err := cache.StartupDBM(strings.TrimSpace(user.GetToken())); err != nil {
+		log.Fatal(err)
+	}
+
+	if err != nil {
+		log.Fatal("Solution didn't run successfully.", "command", command, "err", err)
+	}
+
+	for _, result := range results {
+		if result.Kind == aocgo.BENCH_RESULT {
+			return
+		}
+	}
+
+	log.Fatal("No benchmarks found in the solution's output. Use aocgo.RunSolve or aocgo.Benchmark to run your solvers.")
+}
+
+// Prints a benchmark result as a line of the report, along with how it compares to the last benchmark
+func printBenchResult(result aocgo.Result) {
+	stats := result.Benchmark
+	if stats == nil {
+		return
+	}
+
+	line := fmt.Sprintf("%v%v: %v -- median %v over %v runs (min %v, p95 %v), %v allocs/run, %v/run",
+		styles.Note, result.Title, result.Answer,
+		resources.FormatRuntime(stats.Median), stats.Runs,
+		resources.FormatRuntime(stats.Min), resources.FormatRuntime(stats.P95),
+		stats.AllocsPerRun, resources.FormatBytes(stats.BytesPerRun))
+	line = styles.NeutralAnswerStyle.Render(line)
+
+	if result.Previous != nil {
+		line += fmt.Sprintf(" %v", resources.FormatMedianChange(stats.MedianChange(*result.Previous)))
+	}
+
+	fmt.Println(line)
+}
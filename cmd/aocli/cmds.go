@@ -3,46 +3,116 @@ package main
 // TODO: Update godocs
 
 import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
+	"go.dalton.dog/aocgo"
+	"go.dalton.dog/aocgo/internal/api"
 	"go.dalton.dog/aocgo/internal/cache"
+	"go.dalton.dog/aocgo/internal/config"
 	"go.dalton.dog/aocgo/internal/resources"
+	"go.dalton.dog/aocgo/internal/session"
 )
 
 var Year string
 var Day string
 
 var AnswerPart int
+var WaitForLockout bool
+var SubmitAfterRun bool
+var RunCommand string
+var BenchRuns int
+var BenchTime time.Duration
+var ShowBenchHistory bool
 var OutFilename string
+var TemplateName string
+var PadDay bool
+var SkipInput bool
+var NewAllDays bool
+var NewDays string
+var WaitScaffold bool
+var WaitView bool
+var InputFilename string
 var BaseFilename string
 var ClearUser bool
+var PrivateID string
+var Offline bool
+var FakeServerAddr string
 
 var UserRsrc *resources.User
+var ProjectConfig = config.Default()
+var ShowGlobalLeaderboard bool
 
 func init() {
 
 	rootCmd.PersistentFlags().StringVarP(&Year, "year", "y", "0", "--year [2015...2024]")
 	rootCmd.PersistentFlags().StringVarP(&Day, "day", "d", "0", "--day [1...25]")
+	rootCmd.PersistentFlags().BoolVar(&Offline, "offline", false, "Only use cached data, never contact the site. Can also be set with AOC_OFFLINE.")
 
 	submitCmd.Flags().IntVarP(&AnswerPart, "part", "p", 0, "--part [1|2]")
+	submitCmd.Flags().BoolVarP(&WaitForLockout, "wait", "w", false, "Waits out any lockout, then submits automatically.")
+
+	runCmd.Flags().IntVarP(&AnswerPart, "part", "p", 0, "--part [1|2]")
+	runCmd.Flags().BoolVarP(&SubmitAfterRun, "submit", "s", false, "Submits the answer once the solution finishes.")
+	runCmd.Flags().StringVarP(&RunCommand, "cmd", "c", "", "--cmd \"go run .\"")
+	runCmd.Flags().StringVarP(&InputFilename, "input", "i", "", "Runs the solution against another input file.")
+
+	benchCmd.Flags().IntVarP(&AnswerPart, "part", "p", 0, "--part [1|2]")
+	benchCmd.Flags().IntVarP(&BenchRuns, "runs", "n", aocgo.DEFAULT_BENCH_RUNS, "Maximum number of times to run each solver. 0 for no limit.")
+	benchCmd.Flags().DurationVarP(&BenchTime, "time", "t", aocgo.DEFAULT_BENCH_TIME, "Maximum time to spend running each solver. 0 for no limit.")
+	benchCmd.Flags().StringVarP(&RunCommand, "cmd", "c", "", "--cmd \"go run .\"")
+	benchCmd.Flags().StringVarP(&InputFilename, "input", "i", "", "Benchmarks the solution against another input file.")
+	benchCmd.Flags().BoolVar(&ShowBenchHistory, "history", false, "Shows the stored benchmarks instead of running new ones.")
 
-	getCmd.Flags().StringVarP(&OutFilename, "out", "o", "input.txt", "--out filename")
+	getCmd.Flags().StringVarP(&InputFilename, "out", "o", "", "--out filename. Defaults to the project's input file, or input.txt.")
 
-	newCmd.Flags().StringVarP(&BaseFilename, "base", "b", "base.go", "--base filename")
-	newCmd.Flags().StringVarP(&OutFilename, "out", "o", "main.go", "--out filename")
+	newCmd.Flags().StringVarP(&TemplateName, "template", "t", "", "--template [go|python|rust|<custom>]. Defaults to the project's template, or go.")
+	newCmd.Flags().StringVarP(&BaseFilename, "base", "b", "", "--base filename. Copies a single file instead of using a template.")
+	newCmd.Flags().StringVarP(&OutFilename, "out", "o", "", "--out filename. Name the base file is given, defaults to main.go.")
+	newCmd.Flags().BoolVar(&PadDay, "pad", false, "Zero-pads the day in the new directory, like 2023/05.")
+	newCmd.Flags().BoolVar(&SkipInput, "no-input", false, "Doesn't save the puzzle input into the new directory.")
+	newCmd.Flags().BoolVar(&NewAllDays, "all", false, "Creates every day of the year, along with a README for each.")
+	newCmd.Flags().StringVar(&NewDays, "days", "", "--days 1-10. Creates a range of days, along with a README for each.")
+	newCmd.MarkFlagsMutuallyExclusive("all", "days")
+
+	waitCmd.Flags().BoolVar(&WaitScaffold, "new", false, "Creates the day's directory from a template once the puzzle unlocks, like `aocli new`.")
+	waitCmd.Flags().BoolVar(&WaitView, "view", false, "Views the puzzle once it unlocks.")
+	waitCmd.Flags().StringVarP(&TemplateName, "template", "t", "", "--template [go|python|rust|<custom>]. Used with --new.")
+	waitCmd.Flags().BoolVar(&PadDay, "pad", false, "Zero-pads the day in the new directory. Used with --new.")
 
 	userCmd.Flags().BoolVar(&ClearUser, "clear", false, "Clears the stored puzzle data for a user.")
 
+	leaderboardCmd.Flags().StringVar(&PrivateID, "private", "", "--private <leaderboard id>")
+	leaderboardCmd.Flags().BoolVar(&ShowGlobalLeaderboard, "global", false, "Shows the global leaderboard, even if the project config has a private one.")
+
+	fakeServerCmd.Flags().StringVar(&FakeServerAddr, "addr", "localhost:8080", "--addr host:port")
+	devCmd.AddCommand(fakeServerCmd)
+
+	configCmd.AddCommand(configShowCmd)
+
+	rootCmd.AddCommand(benchCmd)
+	rootCmd.AddCommand(calendarCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(devCmd)
+	rootCmd.AddCommand(examplesCmd)
 	rootCmd.AddCommand(getCmd)
 	rootCmd.AddCommand(healthCmd)
+	rootCmd.AddCommand(historyCmd)
 	rootCmd.AddCommand(leaderboardCmd)
 	rootCmd.AddCommand(newCmd)
 	rootCmd.AddCommand(reloadCmd)
+	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(submitCmd)
 	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(userCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(viewCmd)
+	rootCmd.AddCommand(waitCmd)
 }
 
 var rootCmd = &cobra.Command{
@@ -51,21 +121,30 @@ var rootCmd = &cobra.Command{
 	Args:  cobra.NoArgs,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		// log.SetLevel(log.DebugLevel)
-		var err error
+		if Offline {
+			api.SetOffline(true)
+		}
 
-		UserRsrc, err = resources.NewUser("")
+		loadProjectConfig()
 
+		token, err := session.GetSessionToken(false)
 		if err != nil {
-			log.Fatal("Unable to create user to run requests as. Run `aocli health`.")
-		} else {
-			log.Debug("User loaded", "token", UserRsrc.SessionTok)
+			log.Fatal("Unable to load a session token. Run `aocli health`.", "err", err)
 		}
 
-		err = cache.StartupDBM(UserRsrc.GetToken())
+		err = cache.StartupDBM(strings.TrimSpace(token))
 		if err != nil {
 			log.Fatal(err)
 		}
 
+		UserRsrc, err = resources.NewUser(token)
+
+		if err != nil {
+			log.Fatal("Unable to create user to run requests as. Run `aocli health`.", "err", err)
+		} else {
+			log.Debug("User loaded", "token", UserRsrc.SessionTok)
+		}
+
 	},
 
 	Run: func(cmd *cobra.Command, args []string) {
@@ -75,18 +154,43 @@ var rootCmd = &cobra.Command{
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
 		cache.ShutdownDBM()
 
-		if cmd.Name() != "update" {
+		// Updates aren't checked for when pointed at another server, like the fake one
+		if cmd.Name() != "update" && !api.IsOffline() && os.Getenv(api.BASE_URL_ENV) == "" {
 			CheckForUpdate()
 		}
 	},
 }
 
 var newCmd = &cobra.Command{
-	Use:   "new [-b base.go]",
-	Short: "Copies the given file into the ./<year>/<day>/main.go",
+	Use:   "new [-t template] [-b base.go] [--pad] [--no-input] [--all | --days 1-10]",
+	Short: "Creates a directory for a day's solution from a template, along with its input.",
 	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		New(Year, Day, BaseFilename, OutFilename)
+		opts := NewOptions{
+			Template:  TemplateName,
+			BaseFile:  BaseFilename,
+			OutFile:   OutFilename,
+			Pad:       PadDay,
+			SkipInput: SkipInput,
+		}
+
+		if NewAllDays || NewDays != "" {
+			if cmd.Flags().Changed("day") {
+				log.Fatal("Use --days instead of -d to create more than one day.")
+			}
+			NewYear(UserRsrc, Year, NewDays, opts)
+		} else {
+			New(UserRsrc, Year, Day, opts)
+		}
+	},
+}
+
+var waitCmd = &cobra.Command{
+	Use:   "wait [--new] [--view]",
+	Short: "Counts down to the next puzzle unlock, then loads the puzzle and its input.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		Wait(UserRsrc, WaitScaffold, WaitView, NewOptions{Template: TemplateName, Pad: PadDay})
 	},
 }
 
@@ -95,7 +199,16 @@ var getCmd = &cobra.Command{
 	Short: "Gets the puzzle input and saves it to disk.",
 	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		Get(UserRsrc, Year, Day, OutFilename)
+		Get(UserRsrc, Year, Day, InputFilename)
+	},
+}
+
+var examplesCmd = &cobra.Command{
+	Use:   "examples",
+	Short: "Saves the puzzle's example inputs to example1.txt and example2.txt.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		Examples(UserRsrc, Year, Day)
 	},
 }
 
@@ -108,6 +221,15 @@ var healthCmd = &cobra.Command{
 	},
 }
 
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Shows the answers submitted for a puzzle, or a summary for a year.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		History(UserRsrc, Year, Day)
+	},
+}
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Prints out the current version of the program.",
@@ -127,11 +249,32 @@ var updateCmd = &cobra.Command{
 }
 
 var submitCmd = &cobra.Command{
-	Use:   "submit [-p {1|2}] <answer>",
+	Use:   "submit [-p {1|2}] [--wait] <answer>",
 	Short: "Submits the given answer to a puzzle.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		Submit(UserRsrc, Year, Day, args[0], AnswerPart)
+		if !cmd.Flags().Changed("wait") {
+			WaitForLockout = ProjectConfig.Submit.Wait
+		}
+		Submit(UserRsrc, Year, Day, args[0], AnswerPart, WaitForLockout)
+	},
+}
+
+var runCmd = &cobra.Command{
+	Use:   "run [-p {1|2}] [--submit] [--cmd command] [--input file]",
+	Short: "Runs the solution in the current directory, optionally submitting its answer.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		Run(UserRsrc, Year, Day, RunCommand, InputFilename, AnswerPart, SubmitAfterRun)
+	},
+}
+
+var benchCmd = &cobra.Command{
+	Use:   "bench [-p {1|2}] [-n runs] [-t time] [--cmd command] [--input file] [--history]",
+	Short: "Benchmarks the solution in the current directory and compares it to previous runs.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		Bench(UserRsrc, Year, Day, RunCommand, InputFilename, AnswerPart, BenchRuns, BenchTime, ShowBenchHistory)
 	},
 }
 
@@ -144,6 +287,15 @@ var reloadCmd = &cobra.Command{
 	},
 }
 
+var calendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "Shows a year's calendar with your stars, and opens the puzzle for any day on it.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		Calendar(UserRsrc, Year)
+	},
+}
+
 var viewCmd = &cobra.Command{
 	Use:   "view",
 	Short: "Views the puzzle's page inside of the terminal.",
@@ -163,10 +315,82 @@ var userCmd = &cobra.Command{
 }
 
 var leaderboardCmd = &cobra.Command{
-	Use:   "leaderboard",
+	Use:   "leaderboard [--private id | --global]",
 	Short: "Shows a puzzle's daily leaderboard, or a yearly leaderboard.",
 	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		Leaderboard(Year, Day)
+		if PrivateID == "" && !ShowGlobalLeaderboard {
+			PrivateID = ProjectConfig.Leaderboard.Private
+		}
+		Leaderboard(Year, Day, PrivateID)
+	},
+}
+
+var devCmd = &cobra.Command{
+	Use:   "dev",
+	Short: "Tools for developing against aocli and aocgo.",
+	Args:  cobra.NoArgs,
+	// These don't need a user or cache, so skip the root command's setup
+	PersistentPreRun:  func(cmd *cobra.Command, args []string) {},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {},
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Tools for the project's .aocli.toml config file.",
+	Args:  cobra.NoArgs,
+	// The config doesn't need a user or cache, so skip the root command's setup
+	PersistentPreRun:  func(cmd *cobra.Command, args []string) { loadProjectConfig() },
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {},
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Prints the project config, including defaults for anything not set.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ShowConfig(ProjectConfig)
+	},
+}
+
+var fakeServerCmd = &cobra.Command{
+	Use:   "fake-server [--addr host:port]",
+	Short: "Runs a fake Advent of Code server for local testing.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		FakeServer(FakeServerAddr)
 	},
 }
+
+// Loads the project config from the working directory or a parent, exiting if it can't be read
+func loadProjectConfig() {
+	conf, err := config.Load()
+	if err != nil {
+		log.Fatal("Unable to read project config.", "err", err)
+	}
+	ProjectConfig = conf
+	if err := applyCacheTTLs(conf); err != nil {
+		log.Fatal("Invalid cache config.", "err", err)
+	}
+}
+
+// Sets the cache's TTLs from the project config. Only resources that are safe to load again can be given one.
+func applyCacheTTLs(conf *config.Config) error {
+	types := cache.ResourceTypes()
+	for name, ttlIn := range conf.Cache.TTL {
+		i := slices.IndexFunc(types, func(t string) bool { return strings.EqualFold(t, name) })
+		if i == -1 {
+			return fmt.Errorf("%v can't be given a TTL, only %v can", name, strings.Join(types, ", "))
+		}
+
+		ttl, err := time.ParseDuration(ttlIn)
+		if err != nil || ttl < 0 {
+			return fmt.Errorf("invalid TTL %q for %v", ttlIn, name)
+		}
+
+		if err := cache.SetTTL(types[i], ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"go.dalton.dog/aocgo/internal/cache"
+	"go.dalton.dog/aocgo/internal/config"
+)
+
+func TestApplyCacheTTLs(t *testing.T) {
+	backup := cache.GetTTL(cache.CALENDAR)
+	t.Cleanup(func() { cache.SetTTL(cache.CALENDAR, backup) })
+
+	conf := config.Default()
+	conf.Cache.TTL = map[string]string{"calendar": "1h"}
+	if err := applyCacheTTLs(conf); err != nil {
+		t.Fatal(err)
+	}
+	if ttl := cache.GetTTL(cache.CALENDAR); ttl != time.Hour {
+		t.Errorf("Expected the calendar's TTL to be set, got %v", ttl)
+	}
+
+	// Puzzles and inputs hold submissions and lockouts that reloading them would lose
+	for _, name := range []string{"Puzzles", "UserInputs", "UserData", "Nonsense"} {
+		conf.Cache.TTL = map[string]string{name: "1h"}
+		if err := applyCacheTTLs(conf); err == nil {
+			t.Errorf("Expected a TTL for %v to be rejected", name)
+		}
+	}
+
+	conf.Cache.TTL = map[string]string{"Leaderboards": "soon"}
+	if err := applyCacheTTLs(conf); err == nil {
+		t.Errorf("Expected an invalid duration to be rejected")
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+
+	"go.dalton.dog/aocgo/internal/config"
+	"go.dalton.dog/aocgo/internal/styles"
+
+	"github.com/charmbracelet/log"
+)
+
+// ShowConfig prints the resolved project config, along with the file it was loaded from.
+// Settings that aren't in the file show their defaults.
+// Command: `aocli config show`
+func ShowConfig(conf *config.Config) {
+	source := fmt.Sprintf("Loaded from %v", conf.Path)
+	if conf.Path == "" {
+		source = fmt.Sprintf("No %v found in this directory or any parent, showing the defaults", config.CONFIG_FILENAME)
+	}
+
+	encoded, err := conf.Encode()
+	if err != nil {
+		log.Fatal("Unable to show config.", "err", err)
+	}
+
+	if conf.Layout == "" {
+		encoded = "# No layout set, so the day's directory is inside of the year's directory\n" + encoded
+	}
+
+	fmt.Println(styles.GlobalSpacingStyle.Render(styles.SubtitleStyle.Render(source) + "\n\n" + styles.NormalTextStyle.Render(encoded)))
+}
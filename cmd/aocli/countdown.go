@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"go.dalton.dog/aocgo/internal/styles"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+)
+
+type countdownTickMsg time.Time
+
+type countdownModel struct {
+	spinner   spinner.Model
+	status    string
+	end       time.Time
+	left      time.Duration
+	cancelled bool
+}
+
+// RunCountdown shows a countdown until the end time is reached.
+// Returns false if the user cancelled it with ctrl+c.
+func RunCountdown(end time.Time, status string) bool {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color(styles.UpdateSpinnerColor))
+
+	model := countdownModel{
+		spinner: s,
+		status:  status,
+		end:     end,
+		left:    time.Until(end),
+	}
+
+	finalModel, err := tea.NewProgram(model).Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return !finalModel.(countdownModel).cancelled
+}
+
+func (m countdownModel) Init() tea.Cmd {
+	return tea.Batch(countdownTick(), m.spinner.Tick)
+}
+
+func (m countdownModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			m.cancelled = true
+			return m, tea.Quit
+		}
+
+	case countdownTickMsg:
+		m.left = time.Until(m.end)
+		if m.left <= 0 {
+			return m, tea.Quit
+		}
+		return m, countdownTick()
+	}
+
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return m, cmd
+}
+
+func (m countdownModel) View() string {
+	if m.cancelled {
+		symbol := lipgloss.NewStyle().Foreground(styles.RedTextColor).Render(styles.FailureX)
+		return fmt.Sprintf("\n %s Cancelled\n", symbol)
+	} else if m.left <= 0 {
+		symbol := lipgloss.NewStyle().Foreground(styles.GreenTextColor).Render(styles.Checkmark)
+		return fmt.Sprintf("\n %s Done waiting\n", symbol)
+	}
+
+	return fmt.Sprintf("\n %s %s %s\n %s\n", m.spinner.View(), m.status, formatCountdown(m.left),
+		styles.SubtitleStyle.Render("Press ctrl+c to cancel"))
+}
+
+func countdownTick() tea.Cmd {
+	return tea.Tick(time.Second/4, func(t time.Time) tea.Msg {
+		return countdownTickMsg(t)
+	})
+}
+
+// Formats time left like "1h 02m 03s", leaving off hours when there aren't any
+func formatCountdown(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d >= time.Hour {
+		return fmt.Sprintf("%dh %02dm %02ds", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%dm %02ds", int(d.Minutes()), int(d.Seconds())%60)
+}
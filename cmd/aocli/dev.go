@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.dalton.dog/aocgo/aocfake"
+	"go.dalton.dog/aocgo/internal/styles"
+
+	"github.com/charmbracelet/log"
+)
+
+// FakeServer runs a fake Advent of Code server for testing against.
+// Command: `aocli dev fake-server [--addr localhost:8080]`
+// Params:
+//
+//	(Opt) addr - Address for the server to listen on
+func FakeServer(addr string) {
+	fake := aocfake.New()
+
+	fmt.Println(styles.GlobalSpacingStyle.Render(styles.NormalTextStyle.Render(fmt.Sprintf(
+		"Fake Advent of Code server listening on http://%v\n\nPoint aocli and aocgo at it with:\n\n  export AOC_BASE_URL=http://%v\n  export AOC_SESSION_TOKEN=%v\n\nWhile AOC_BASE_URL is set, AOC_SESSION_TOKEN is used over ~/.config/aocgo/session.token,\nand everything is cached separately from the real site.\n",
+		addr, addr, fake.Token))))
+
+	if err := http.ListenAndServe(addr, fake); err != nil {
+		log.Fatal("Fake server stopped!", "err", err)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.dalton.dog/aocgo/aocfake"
+	"go.dalton.dog/aocgo/internal/api"
+	"go.dalton.dog/aocgo/internal/cache"
+	"go.dalton.dog/aocgo/internal/config"
+)
+
+// Starts the fake server the same way `aocli dev fake-server` does, and points the CLI at it
+func startDevServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	go FakeServer(addr)
+
+	baseURL := "http://" + addr
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		if resp, err := http.Get(baseURL); err == nil {
+			resp.Body.Close()
+			break
+		} else if time.Since(start) > 5*time.Second {
+			t.Fatalf("Fake server never started: %v", err)
+		}
+	}
+
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+
+	// A real token in the usual file shouldn't be sent to the fake server
+	tokenDir := filepath.Join(dir, ".config", "aocgo")
+	if err := os.MkdirAll(tokenDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tokenDir, "session.token"), []byte("real-session-token"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(api.BASE_URL_ENV, baseURL)
+	t.Setenv("AOC_SESSION_TOKEN", aocfake.TOKEN)
+
+	cwd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	backupCacheFile := cache.CacheFile
+	cache.CacheFile = filepath.Join(dir, "%v.db")
+
+	t.Cleanup(func() {
+		os.Chdir(cwd)
+		cache.CacheFile = backupCacheFile
+		api.MasterClient = nil
+		ProjectConfig = config.Default()
+	})
+
+	return baseURL
+}
+
+// Runs aocli with the given arguments
+func runCLI(t *testing.T, args ...string) {
+	rootCmd.SetArgs(args)
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("aocli %v failed: %v", strings.Join(args, " "), err)
+	}
+}
+
+func TestFakeServerRoundTrip(t *testing.T) {
+	baseURL := startDevServer(t)
+	expected := aocfake.New().GetPuzzle(2015, 1)
+
+	runCLI(t, "get", "-y", "2015", "-d", "1", "-o", "input.txt")
+
+	input, err := os.ReadFile("input.txt")
+	if err != nil || string(input) != expected.Input {
+		t.Fatalf("Expected the fake server's input to be saved, got %q (err: %v)", input, err)
+	}
+
+	runCLI(t, "submit", "-y", "2015", "-d", "1", "-p", "1", expected.AnswerOne)
+
+	req, _ := http.NewRequest(http.MethodGet, baseURL+"/2015/day/1", nil)
+	req.AddCookie(&http.Cookie{Name: "session", Value: aocfake.TOKEN})
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	page, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(page), "<code>"+expected.AnswerOne+"</code>") {
+		t.Errorf("Expected the fake server to have recorded the submitted answer")
+	}
+}
@@ -57,10 +57,46 @@ var (
 		desc: "Shows the help information for the program as a whole, or for a specific command",
 	}
 
+	benchHelpText = helpText{
+		name: "bench",
+		use:  "aocli bench [-p part] [-n runs] [-t time] [--cmd command] [--input file] [--history]",
+		desc: "Benchmarks the solution in the current directory and compares each part's median runtime to its last benchmark. Pass --history to show every stored benchmark.",
+	}
+
+	calendarHelpText = helpText{
+		name: "calendar",
+		use:  "aocli calendar [year]",
+		desc: "Shows a year's calendar with your stars and its art. Use the arrow keys to move between days, and enter to open a day's puzzle.",
+	}
+
+	configHelpText = helpText{
+		name: "config",
+		use:  "aocli config show",
+		desc: "Prints the project's .aocli.toml config, found by walking up from the current directory, with defaults for anything it doesn't set.",
+	}
+
+	examplesHelpText = helpText{
+		name: "examples",
+		use:  "aocli examples [year] [day]",
+		desc: "Saves the example inputs from the puzzle's page to example1.txt and example2.txt, and prints their expected answers.",
+	}
+
+	historyHelpText = helpText{
+		name: "history",
+		use:  "aocli history [year] [day]",
+		desc: "Shows every answer submitted for a puzzle, or a summary of a year's submissions if no day is given.",
+	}
+
 	leaderboardHelpText = helpText{
 		name: "leaderboard",
-		use:  "aocli leaderboard [year] [day]",
-		desc: "Shows the leaderboard for a given year, or a given year and day",
+		use:  "aocli leaderboard [year] [day] [--private id | --global]",
+		desc: "Shows the leaderboard for a given year, or a given year and day. Pass a private leaderboard ID to view that instead, or --global to skip the one in the project config.",
+	}
+
+	newHelpText = helpText{
+		name: "new",
+		use:  "aocli new [year] [day] [-t template] [-b base.go] [--pad] [--no-input] [--all | --days 1-10]",
+		desc: "Creates a directory for a day's solution from a template (go, python, rust, or your own), and saves the puzzle input into it. Pass --all or --days to create many days at once, skipping any that already exist.",
 	}
 
 	reloadHelpText = helpText{
@@ -69,6 +105,12 @@ var (
 		desc: "Reloads the page data for the puzzle on a given year and day",
 	}
 
+	runHelpText = helpText{
+		name: "run",
+		use:  "aocli run [-p part] [--submit] [--cmd command] [--input file]",
+		desc: "Runs the solution in the current directory, captures the answer it prints on an \"ANSWER:\" line, and optionally submits it.",
+	}
+
 	submitHelpText = helpText{
 		name: "submit",
 		desc: `Submits an answer to the puzzle on the user's behalf and prints out the response.
@@ -81,6 +123,12 @@ Attempts to derive the year and day from current and parent directory name.`,
 		desc: "Loads all puzzle information for a user and will display their star count for each year and day in a table.",
 	}
 
+	waitHelpText = helpText{
+		name: "wait",
+		use:  "aocli wait [--new] [-t template] [--pad] [--view]",
+		desc: "Shows a countdown to the next puzzle unlock at midnight ET, then loads the puzzle and its input. Pass --new to create the day's directory and --view to view the puzzle.",
+	}
+
 	viewHelpText = helpText{
 		name: "view - Pretty prints the puzzle's page data to the screen",
 		use:  "aocli view [year] [day]",
@@ -99,14 +147,22 @@ var HelpTextMap map[string]helpText = map[string]helpText{
 	"aocli": aocliHelpText,
 
 	// Commands help text
+	"bench":       benchHelpText,
+	"calendar":    calendarHelpText,
+	"config":      configHelpText,
+	"examples":    examplesHelpText,
 	"get":         getHelpText,
 	"health":      healthHelpText,
 	"help":        helpHelpText,
+	"history":     historyHelpText,
 	"leaderboard": leaderboardHelpText,
+	"new":         newHelpText,
 	"reload":      reloadHelpText,
+	"run":         runHelpText,
 	"submit":      submitHelpText,
 	"user":        userHelpText,
 	"view":        viewHelpText,
+	"wait":        waitHelpText,
 }
 
 type helpText struct {
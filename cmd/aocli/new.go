@@ -0,0 +1,416 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"go.dalton.dog/aocgo/internal/api"
+	"go.dalton.dog/aocgo/internal/config"
+	"go.dalton.dog/aocgo/internal/resources"
+	"go.dalton.dog/aocgo/internal/utils"
+
+	"github.com/charmbracelet/log"
+)
+
+// Templates that come with aocli. Each directory is a template, and a ".tmpl" suffix is removed from each file's name.
+//
+//go:embed templates
+var builtinTemplates embed.FS
+
+// Suffix that's removed from template file names, so templates for Go code aren't built as part of aocli
+const TEMPLATE_SUFFIX = ".tmpl"
+
+// README written into each day's directory when scaffolding more than one day
+const README_FILENAME = "README.md"
+const README_TEMPLATE = `# {{.Year}} Day {{.Day}}{{if .Title}}: {{.Title}}{{end}}
+
+{{.URL}}
+`
+
+// NewOptions controls how `aocli new` scaffolds a day
+type NewOptions struct {
+	// Name of the template to use. If empty, a base file is used if it exists, otherwise the config's template.
+	Template string
+
+	// Single file to use instead of a template, and the name it's given
+	BaseFile string
+	OutFile  string
+
+	// Zero-pad the day in the directory name
+	Pad bool
+
+	// Skip saving the puzzle's input into the new directory
+	SkipInput bool
+
+	// Write a README with the puzzle's title and URL into the new directory
+	Readme bool
+}
+
+// TemplateData is what's available to templates, like {{.Year}} or {{.Title}}
+type TemplateData struct {
+	Year      int
+	Day       int
+	DayPadded string
+
+	// Name of the puzzle, like "Not Quite Lisp". Empty if the puzzle couldn't be loaded.
+	Title string
+	URL   string
+
+	// Name of the input file saved next to the solution
+	Input string
+}
+
+// New creates a directory for a day's solution from a template, then saves the puzzle's input into it.
+// If the date isn't provided, it defaults to the latest puzzle that has unlocked.
+// Command: `aocli new [-y yyyy -d dd -t template --pad --no-input]`
+// Params:
+//
+//	(Opt) year     - 2 or 4 digit year (16 or 2016)
+//	(Opt) day      - 1 or 2 digit day (1, 01, 21)
+//	(Opt) template - Name of a template, like "go", "python", or "rust"
+//	(Opt) base     - Single file to copy instead of a template, like the original base.go
+//	(Opt) pad      - Zero-pad the day in the directory name, like 2023/05
+//	(Opt) no-input - Don't save the puzzle's input into the new directory
+func New(user *resources.User, yearIn, dayIn string, opts NewOptions) {
+	year, day := getNewYearAndDay(yearIn, dayIn)
+	newDay(user, year, day, opts)
+}
+
+// Creates a day's directory for a date that's already been checked, and logs what was created
+func newDay(user *resources.User, year, day int, opts NewOptions) {
+	result := scaffoldDay(user, year, day, getDayDir(year, day, opts.Pad), opts)
+	for _, path := range result.existing {
+		log.Warn("File already exists, skipping it.", "path", path)
+	}
+	for _, path := range result.created {
+		log.Info("Created file.", "path", path)
+	}
+	if result.warning != "" {
+		log.Warn(result.warning, "year", year, "day", day)
+	}
+	if result.err != nil {
+		log.Fatal("Unable to create day.", "dir", result.dir, "err", result.err)
+	}
+
+	if len(result.created) == 0 {
+		log.Warn("Every file already exists, nothing was created.", "dir", result.dir)
+	}
+}
+
+// Picks the date for a new day, defaulting to the latest puzzle that has unlocked
+func getNewYearAndDay(yearIn, dayIn string) (int, int) {
+	maxYear, maxDay := utils.GetCurrentMaxYearAndDay()
+
+	year := maxYear
+	if yearIn != "0" {
+		var err error
+		year, err = utils.ParseYear(yearIn)
+		if err != nil {
+			log.Fatal("Couldn't parse provided year argument.", "err", err)
+		}
+	}
+
+	if dayIn == "0" {
+		if year != maxYear {
+			log.Fatal("Must provide a day with the -d option for past years.")
+		}
+		return year, maxDay
+	}
+
+	day, err := utils.ParseDay(dayIn)
+	if err != nil {
+		log.Fatal("Couldn't parse provided day argument.", "err", err)
+	}
+
+	if err := utils.CheckDay(year, day); err != nil {
+		log.Fatal(err)
+	}
+
+	return year, day
+}
+
+// Returns the directory for a day, using the project's layout or "year/day" if there isn't one
+func getDayDir(year, day int, pad bool) string {
+	layout := ProjectConfig.Layout
+	if layout == "" && (pad || ProjectConfig.New.Pad) {
+		layout = "{year}/{day:02}"
+	} else if layout == "" {
+		layout = "{year}/{day}"
+	}
+
+	return filepath.Join(getProjectRoot(), filepath.FromSlash(utils.FormatLayout(layout, year, day)))
+}
+
+// Returns the project's root directory, or the working directory if there isn't a config file
+func getProjectRoot() string {
+	if root := ProjectConfig.Root(); root != "" {
+		return root
+	}
+	return "."
+}
+
+// Outcome of scaffolding a single day
+type scaffoldResult struct {
+	year int
+	day  int
+	dir  string
+
+	// Title of the puzzle, if it could be loaded
+	title string
+
+	// Paths of the files that were created, and the ones that were skipped because they already existed
+	created  []string
+	existing []string
+
+	// Set when the puzzle or its input couldn't be loaded, which doesn't stop the files from being created
+	warning string
+	err     error
+}
+
+// Creates a day's files in the given directory, and saves its input there if the puzzle has unlocked.
+// Existing files are never overwritten.
+func scaffoldDay(user *resources.User, year, day int, dir string, opts NewOptions) scaffoldResult {
+	result := scaffoldResult{year: year, day: day, dir: dir}
+
+	data := TemplateData{
+		Year:      year,
+		Day:       day,
+		DayPadded: fmt.Sprintf("%02d", day),
+		URL:       api.DayURL(year, day),
+		Input:     utils.FormatLayout(ProjectConfig.Input, year, day),
+	}
+	if data.Input == "" {
+		data.Input = config.DEFAULT_INPUT_FILENAME
+	}
+
+	// The title and input are nice to have, so the files are still created if the puzzle can't be loaded
+	var puzzle *resources.Puzzle
+	if isUnlocked(year, day) {
+		var err error
+		puzzle, err = resources.LoadOrCreatePuzzle(year, day, user.GetToken())
+		if err != nil {
+			result.warning = fmt.Sprintf("Unable to load puzzle, the title and input will be missing: %v", err)
+			puzzle = nil
+		} else {
+			data.Title = puzzle.GetName()
+			result.title = data.Title
+		}
+	} else {
+		result.warning = "Puzzle hasn't unlocked yet, so the title and input will be missing."
+	}
+
+	files, templated, err := loadTemplateFiles(opts)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	// Files that are copied as is are marked so they're never rendered
+	type dayFile struct {
+		contents  string
+		templated bool
+	}
+
+	paths := map[string]dayFile{}
+	for name, contents := range files {
+		paths[filepath.Join(dir, filepath.FromSlash(name))] = dayFile{contents, templated}
+	}
+
+	// The README is always a template, even when the solution is a plain base file
+	if opts.Readme {
+		paths[filepath.Join(dir, README_FILENAME)] = dayFile{README_TEMPLATE, true}
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(paths)) {
+		path, ok, err := renderTemplateFile(path, paths[path].contents, data, paths[path].templated)
+		if err != nil {
+			result.err = err
+			return result
+		} else if ok {
+			result.created = append(result.created, path)
+		} else {
+			result.existing = append(result.existing, path)
+		}
+	}
+
+	if opts.SkipInput || puzzle == nil {
+		return result
+	}
+
+	inputPath := filepath.Join(dir, data.Input)
+	if _, err := os.Stat(inputPath); err == nil {
+		result.existing = append(result.existing, inputPath)
+		return result
+	}
+
+	input, err := puzzle.GetUserInput()
+	if err != nil {
+		result.warning = fmt.Sprintf("Unable to load puzzle input: %v", err)
+		return result
+	}
+
+	if err := os.MkdirAll(filepath.Dir(inputPath), 0755); err != nil {
+		result.err = err
+	} else if err := os.WriteFile(inputPath, input, 0644); err != nil {
+		result.err = err
+	} else {
+		result.created = append(result.created, inputPath)
+	}
+
+	return result
+}
+
+// Returns true if the puzzle for the given date has unlocked
+func isUnlocked(year, day int) bool {
+	maxYear, maxDay := utils.GetCurrentMaxYearAndDay()
+	return year < maxYear || (year == maxYear && day <= maxDay)
+}
+
+// Loads the files for the template, mapped from their slash separated path to their contents.
+// A base file is used if one was asked for, or if it exists and no template was asked for.
+// Returns false if the files are a base file, which is copied as is instead of being rendered as a template.
+func loadTemplateFiles(opts NewOptions) (map[string]string, bool, error) {
+	baseFile := opts.BaseFile
+	if baseFile == "" && opts.Template == "" {
+		baseFile = resolveProjectPath(ProjectConfig.New.Base)
+		if _, err := os.Stat(baseFile); err != nil {
+			baseFile = ""
+		}
+	}
+
+	if baseFile != "" {
+		contents, err := os.ReadFile(baseFile)
+		if err != nil {
+			return nil, false, err
+		}
+
+		outFile := opts.OutFile
+		if outFile == "" {
+			outFile = ProjectConfig.New.Out
+		}
+		return map[string]string{filepath.ToSlash(outFile): string(contents)}, false, nil
+	}
+
+	name := opts.Template
+	if name == "" {
+		name = ProjectConfig.New.Template
+	}
+
+	templateFS, err := findTemplate(name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	files := map[string]string{}
+	err = fs.WalkDir(templateFS, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		contents, err := fs.ReadFile(templateFS, path)
+		if err != nil {
+			return err
+		}
+
+		files[strings.TrimSuffix(path, TEMPLATE_SUFFIX)] = string(contents)
+		return nil
+	})
+
+	return files, true, err
+}
+
+// Finds a template by name, looking in the project's templates directory before the built-in templates
+func findTemplate(name string) (fs.FS, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return nil, fmt.Errorf("%q is not a valid template name", name)
+	}
+
+	if ProjectConfig.New.Templates != "" {
+		dir := filepath.Join(resolveProjectPath(ProjectConfig.New.Templates), name)
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return os.DirFS(dir), nil
+		}
+	}
+
+	templateFS, err := fs.Sub(builtinTemplates, "templates/"+name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fs.Stat(templateFS, "."); errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("No template named %q. The built-in templates are: %v", name, strings.Join(listBuiltinTemplates(), ", "))
+	}
+
+	return templateFS, nil
+}
+
+// Lists the names of the templates that come with aocli
+func listBuiltinTemplates() []string {
+	var names []string
+	entries, _ := builtinTemplates.ReadDir("templates")
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
+
+// Renders a template file to the path, which can also use template variables.
+// If templated is false, the file is copied to the path as is.
+// Returns the rendered path, and false without an error if the file already exists.
+func renderTemplateFile(path, contents string, data TemplateData, templated bool) (string, bool, error) {
+	renderedPath, rendered := path, contents
+	if templated {
+		var err error
+		if renderedPath, err = renderTemplate(path, path, data); err != nil {
+			return path, false, err
+		}
+	}
+
+	if _, err := os.Stat(renderedPath); err == nil {
+		return renderedPath, false, nil
+	}
+
+	if templated {
+		var err error
+		if rendered, err = renderTemplate(path, contents, data); err != nil {
+			return renderedPath, false, err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(renderedPath), 0755); err != nil {
+		return renderedPath, false, err
+	}
+
+	return renderedPath, true, os.WriteFile(renderedPath, []byte(rendered), 0644)
+}
+
+func renderTemplate(name, text string, data TemplateData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// Resolves a path from the config against the project's root directory
+func resolveProjectPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(getProjectRoot(), path)
+}
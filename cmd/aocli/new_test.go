@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.dalton.dog/aocgo/internal/config"
+	"go.dalton.dog/aocgo/internal/resources"
+)
+
+func TestScaffoldDayBaseFile(t *testing.T) {
+	root := t.TempDir()
+	ProjectConfig = config.Default()
+	ProjectConfig.Path = filepath.Join(root, config.CONFIG_FILENAME)
+	t.Cleanup(func() { ProjectConfig = config.Default() })
+
+	// Base files are plain code, so anything that looks like a template has to be copied as is
+	base := "package main\n\nvar dirs = [][2]int{{0, 1}, {1, 0}}\n\n// {{.Year}}\n"
+	if err := os.WriteFile(filepath.Join(root, config.DEFAULT_BASE_FILENAME), []byte(base), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A day that hasn't unlocked, so nothing is loaded from the site
+	dir := filepath.Join(root, "2099", "1")
+	result := scaffoldDay(&resources.User{}, 2099, 1, dir, NewOptions{Readme: true})
+	if result.err != nil {
+		t.Fatal(result.err)
+	}
+
+	out, err := os.ReadFile(filepath.Join(dir, config.DEFAULT_OUT_FILENAME))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != base {
+		t.Errorf("Expected the base file to be copied as is, got %q", out)
+	}
+
+	readme, err := os.ReadFile(filepath.Join(dir, README_FILENAME))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(readme), "# 2099 Day 1") {
+		t.Errorf("Expected the README to still be rendered, got %q", readme)
+	}
+}
+
+func TestScaffoldDayTemplate(t *testing.T) {
+	root := t.TempDir()
+	ProjectConfig = config.Default()
+	ProjectConfig.Path = filepath.Join(root, config.CONFIG_FILENAME)
+	t.Cleanup(func() { ProjectConfig = config.Default() })
+
+	dir := filepath.Join(root, "2099", "1")
+	result := scaffoldDay(&resources.User{}, 2099, 1, dir, NewOptions{Template: "go"})
+	if result.err != nil {
+		t.Fatal(result.err)
+	}
+
+	out, err := os.ReadFile(filepath.Join(dir, "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(out), "{{") {
+		t.Errorf("Expected a named template to be rendered, got %q", out)
+	}
+}
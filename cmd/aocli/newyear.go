@@ -0,0 +1,269 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.dalton.dog/aocgo/internal/resources"
+	"go.dalton.dog/aocgo/internal/styles"
+	"go.dalton.dog/aocgo/internal/utils"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+)
+
+// NewYear creates every day in a range from a template, the same way New does for a single day.
+// Each day also gets a README with the puzzle's title and URL. Days whose directory already exists are skipped,
+// and inputs are only fetched for days that have unlocked.
+// Command: `aocli new [-y yyyy] --all` or `aocli new [-y yyyy] --days 1-10`
+// Params:
+//
+//	(Opt) year - 2 or 4 digit year (16 or 2016). Defaults to the latest year.
+//	(Opt) days - Range of days like "1-10", or a single day. Defaults to every day of that year's event.
+func NewYear(user *resources.User, yearIn, daysIn string, opts NewOptions) {
+	year, _ := utils.GetCurrentMaxYearAndDay()
+	if yearIn != "0" {
+		var err error
+		year, err = utils.ParseYear(yearIn)
+		if err != nil {
+			log.Fatal("Couldn't parse provided year argument.", "err", err)
+		}
+	}
+
+	calendar := resources.GetEventCalendar(year)
+	first, last := 1, calendar.NumDays
+	if daysIn != "" {
+		var err error
+		first, last, err = parseDayRange(daysIn)
+		if err != nil {
+			log.Fatal("Couldn't parse provided days.", "err", err)
+		}
+
+		if !calendar.HasDay(last) {
+			log.Fatal("Provided days go past the end of the event.", "year", year, "days", calendar.NumDays)
+		}
+	}
+
+	// Templates are checked up front so a bad one doesn't fail every day
+	if _, _, err := loadTemplateFiles(opts); err != nil {
+		log.Fatal("Unable to load template.", "err", err)
+	}
+
+	opts.Readme = true
+
+	model := newScaffoldModel(user, year, first, last, opts)
+	finalModel, err := tea.NewProgram(model).Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if finalModel.(scaffoldModel).cancelled {
+		os.Exit(1)
+	}
+}
+
+// Parses a range of days like "1-10", or a single day like "5"
+func parseDayRange(daysIn string) (int, int, error) {
+	firstIn, lastIn, isRange := strings.Cut(daysIn, "-")
+	if !isRange {
+		lastIn = firstIn
+	}
+
+	first, err := parseDay(firstIn)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	last, err := parseDay(lastIn)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if first > last {
+		return 0, 0, fmt.Errorf("First day %v is after the last day %v.", first, last)
+	}
+
+	return first, last, nil
+}
+
+func parseDay(dayIn string) (int, error) {
+	day, err := utils.ParseDay(dayIn)
+	if err == nil && day == 0 {
+		err = fmt.Errorf("%q is not a day.", dayIn)
+	}
+	return day, err
+}
+
+// Message to start scaffolding the first day
+type scaffoldStartMsg struct{}
+
+// Message sent once a day is done being scaffolded
+type scaffoldDoneMsg scaffoldResult
+
+// States for a day in the progress list
+const (
+	SCAFFOLD_PENDING = iota
+	SCAFFOLD_WORKING
+	SCAFFOLD_CREATED
+	SCAFFOLD_SKIPPED
+	SCAFFOLD_FAILED
+)
+
+type scaffoldRow struct {
+	day    int
+	state  int
+	result scaffoldResult
+}
+
+// scaffoldModel is the BubbleTea model that scaffolds each day in order and lists their progress
+type scaffoldModel struct {
+	user *resources.User
+	year int
+	opts NewOptions
+
+	rows    []scaffoldRow
+	current int
+
+	spinner   spinner.Model
+	finished  bool
+	cancelled bool
+}
+
+func newScaffoldModel(user *resources.User, year, first, last int, opts NewOptions) scaffoldModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color(styles.UpdateSpinnerColor))
+
+	model := scaffoldModel{
+		user:    user,
+		year:    year,
+		opts:    opts,
+		spinner: s,
+	}
+
+	for day := first; day <= last; day++ {
+		model.rows = append(model.rows, scaffoldRow{day: day})
+	}
+
+	return model
+}
+
+func (m scaffoldModel) Init() tea.Cmd {
+	return tea.Batch(func() tea.Msg { return scaffoldStartMsg{} }, m.spinner.Tick)
+}
+
+func (m scaffoldModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			m.cancelled = true
+			return m, tea.Quit
+		}
+
+	case scaffoldStartMsg:
+		return m, m.scaffoldCurrent()
+
+	case scaffoldDoneMsg:
+		row := &m.rows[m.current]
+		row.result = scaffoldResult(msg)
+		if row.result.err != nil {
+			row.state = SCAFFOLD_FAILED
+		} else {
+			row.state = SCAFFOLD_CREATED
+		}
+
+		m.current++
+		return m, m.scaffoldCurrent()
+	}
+
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return m, cmd
+}
+
+// Scaffolds the current day. Days whose directory already exists are skipped without loading anything.
+func (m *scaffoldModel) scaffoldCurrent() tea.Cmd {
+	for m.current < len(m.rows) {
+		row := &m.rows[m.current]
+		dir := getDayDir(m.year, row.day, m.opts.Pad)
+
+		if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+			row.state = SCAFFOLD_WORKING
+			user, year, day, opts := m.user, m.year, row.day, m.opts
+			return func() tea.Msg {
+				return scaffoldDoneMsg(scaffoldDay(user, year, day, dir, opts))
+			}
+		}
+
+		row.state = SCAFFOLD_SKIPPED
+		row.result = scaffoldResult{year: m.year, day: row.day, dir: dir}
+		m.current++
+	}
+
+	m.finished = true
+	return tea.Quit
+}
+
+func (m scaffoldModel) View() string {
+	var out strings.Builder
+	out.WriteString(styles.SubtitleStyle.Render(fmt.Sprintf("Creating %v, days %v to %v",
+		m.year, m.rows[0].day, m.rows[len(m.rows)-1].day)) + "\n\n")
+
+	created, skipped, failed := 0, 0, 0
+	for _, row := range m.rows {
+		out.WriteString(m.viewRow(row) + "\n")
+
+		switch row.state {
+		case SCAFFOLD_CREATED:
+			created++
+		case SCAFFOLD_SKIPPED:
+			skipped++
+		case SCAFFOLD_FAILED:
+			failed++
+		}
+	}
+
+	if m.cancelled {
+		out.WriteString("\n" + styles.SubtitleStyle.Render("Cancelled"))
+	} else if m.finished {
+		out.WriteString("\n" + styles.SubtitleStyle.Render(fmt.Sprintf("Created %v, skipped %v, failed %v", created, skipped, failed)))
+	} else {
+		out.WriteString("\n" + styles.SubtitleStyle.Render("Press ctrl+c to cancel"))
+	}
+
+	return styles.GlobalSpacingStyle.Render(out.String()) + "\n"
+}
+
+func (m scaffoldModel) viewRow(row scaffoldRow) string {
+	label := fmt.Sprintf("Day %02d", row.day)
+	if row.result.title != "" {
+		label += " - " + row.result.title
+	}
+
+	switch row.state {
+	case SCAFFOLD_WORKING:
+		return fmt.Sprintf("%v%v", m.spinner.View(), label)
+
+	case SCAFFOLD_CREATED:
+		symbol := lipgloss.NewStyle().Foreground(styles.GreenTextColor).Render(styles.Checkmark)
+		line := fmt.Sprintf("%v%v %v", symbol, label, styles.SubtitleStyle.Render(row.result.dir))
+		if row.result.warning != "" {
+			line += "\n   " + styles.WarningAnswerStyle.Render(row.result.warning)
+		}
+		return line
+
+	case SCAFFOLD_SKIPPED:
+		return styles.SubtitleStyle.Render(fmt.Sprintf("%v%v already exists, skipped", styles.Note, label))
+
+	case SCAFFOLD_FAILED:
+		symbol := lipgloss.NewStyle().Foreground(styles.RedTextColor).Render(styles.FailureX)
+		return fmt.Sprintf("%v%v %v", symbol, label, styles.WarningAnswerStyle.Render(row.result.err.Error()))
+	}
+
+	return styles.SubtitleStyle.Render("  " + label)
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.dalton.dog/aocgo"
+	"go.dalton.dog/aocgo/internal/api"
+	"go.dalton.dog/aocgo/internal/cache"
+	"go.dalton.dog/aocgo/internal/config"
+	"go.dalton.dog/aocgo/internal/resources"
+	"go.dalton.dog/aocgo/internal/styles"
+	"go.dalton.dog/aocgo/internal/utils"
+
+	"github.com/charmbracelet/log"
+)
+
+// Run will run a solution in the current directory and capture the answer it prints.
+// Solutions using aocgo.RunSolve and aocgo.RunTest report their results as JSON, which is shown as a summary.
+// Otherwise, the answer is found on a line starting with "ANSWER:" or "ANSWER <part>:", so any language can be used.
+// Command: `aocli run [-p {1|2} --submit --cmd "go run ." --input file]`
+// Params:
+//
+//	(Opt) part    - Part to run. Passed to the solution in AOC_PART, so solutions using aocgo only run that part's solvers.
+//	(Opt) submit  - Submit the captured answer once the solution finishes, unless any of its tests failed
+//	(Opt) command - Command that runs the solution. Defaults to AOCLI_RUN_CMD, then the project config, then "go run ."
+//	(Opt) input   - Input file to run the solution against instead of the puzzle's input
+func Run(user *resources.User, yearIn, dayIn, command, input string, part int, submit bool) {
+	year, day := getSubmitYearAndDay(yearIn, dayIn)
+
+	if part < 0 || part > 2 {
+		log.Fatal("Part must be 1 or 2.")
+	}
+
+	command = getRunCommand(command)
+
+	// Solutions often print both parts, so only the part that's next gets submitted
+	if submit && part == 0 {
+		part = getNextPart(user, year, day)
+	}
+
+	env := getInputEnv(input)
+
+	// Solutions using aocgo open the cache themselves, so it can't be held open while they run
+	cache.ShutdownDBM()
+	output, results, timeTaken, err := runSolution(command, year, day, part, env...)
+	if err := cache.StartupDBM(strings.TrimSpace(user.GetToken())); err != nil {
+		log.Fatal(err)
+	}
+
+	if err != nil {
+		log.Fatal("Solution didn't run successfully.", "command", command, "err", err)
+	}
+
+	answer, answerPart, ok := findResultAnswer(results, part)
+	if !ok {
+		answer, answerPart, ok = utils.FindMarkedAnswer(output, part)
+	}
+	if !ok {
+		log.Fatalf("No answer found in the solution's output. Use aocgo.RunSolve, or print a line like \"%v: 1234\" or \"%v %v: 1234\".",
+			utils.ANSWER_MARKER, utils.ANSWER_MARKER, max(part, 1))
+	}
+
+	if part == 0 {
+		part = answerPart
+	}
+
+	partStr := "?"
+	if part != 0 {
+		partStr = strconv.Itoa(part)
+	}
+
+	fmt.Println(styles.GlobalSpacingStyle.Render(styles.NormalTextStyle.Render(
+		fmt.Sprintf("Answer (Part %v): %v\nRuntime: %v", partStr, answer, timeTaken.Round(time.Microsecond)))))
+
+	if !submit {
+		return
+	}
+
+	if input != "" {
+		fmt.Println(styles.WarningAnswerStyle.Render("The solution was run against another input, answer not submitted!"))
+		return
+	}
+
+	if failed := countFailedTests(results, part); failed > 0 && !ProjectConfig.Submit.AllowFailedTests {
+		fmt.Println(styles.WarningAnswerStyle.Render(fmt.Sprintf("%v test(s) failed, answer not submitted!", failed)))
+		return
+	}
+
+	submitAnswer(user, year, day, answer, part, ProjectConfig.Submit.Wait)
+}
+
+// Picks the command that runs the solution, falling back to AOCLI_RUN_CMD, then the project config
+func getRunCommand(command string) string {
+	if command == "" {
+		command = os.Getenv("AOCLI_RUN_CMD")
+	}
+	if command == "" {
+		command = ProjectConfig.Run.Command
+	}
+	if command == "" {
+		command = config.DEFAULT_RUN_CMD
+	}
+	return command
+}
+
+// Returns the environment that points aocgo at an input file, so solutions can be run against other inputs
+func getInputEnv(input string) []string {
+	if input == "" {
+		return nil
+	}
+
+	path, err := filepath.Abs(input)
+	if err != nil {
+		log.Fatal("Unable to find input file.", "input", input, "err", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		log.Fatal("Unable to find input file.", "input", input, "err", err)
+	}
+
+	return []string{fmt.Sprintf("%v=%v", aocgo.INPUT_ENV, path)}
+}
+
+// Runs the solution command through the system's shell. Output is echoed as it's printed,
+// except for aocgo results which are collected and printed as a report.
+// Any extra environment variables are passed along to the solution.
+func runSolution(command string, year, day, part int, extraEnv ...string) (string, []aocgo.Result, time.Duration, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("AOC_YEAR=%v", year),
+		fmt.Sprintf("AOC_DAY=%v", day),
+		fmt.Sprintf("%v=%v", aocgo.PART_ENV, part),
+		aocgo.OUTPUT_ENV+"=json",
+	)
+	if api.IsOffline() {
+		cmd.Env = append(cmd.Env, "AOC_OFFLINE=true")
+	}
+	cmd.Env = append(cmd.Env, extraEnv...)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", nil, 0, err
+	}
+
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		return "", nil, 0, err
+	}
+
+	var output strings.Builder
+	var results []aocgo.Result
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if result, ok := aocgo.ParseResult(line); ok {
+			results = append(results, result)
+			printResult(result)
+			continue
+		}
+
+		fmt.Println(line)
+		output.WriteString(line + "\n")
+	}
+
+	err = cmd.Wait()
+	timeTaken := time.Since(start)
+
+	return output.String(), results, timeTaken, err
+}
+
+// Prints a single aocgo result as a line of the run's report
+func printResult(result aocgo.Result) {
+	timeTaken := result.Runtime.Round(time.Microsecond)
+	if result.Kind == aocgo.BENCH_RESULT {
+		printBenchResult(result)
+	} else if result.Kind == aocgo.SOLVE_RESULT && result.Outcome != "" {
+		fmt.Println(styles.NeutralAnswerStyle.Render(fmt.Sprintf("%v%v: %v (%v) -- Submitted: %v", styles.Note, result.Title, result.Answer, timeTaken, result.Outcome)))
+	} else if result.Kind == aocgo.SOLVE_RESULT {
+		fmt.Println(styles.NeutralAnswerStyle.Render(fmt.Sprintf("%v%v: %v (%v)", styles.Note, result.Title, result.Answer, timeTaken)))
+	} else if result.Passed {
+		fmt.Println(styles.CorrectAnswerStyle.Render(fmt.Sprintf("%v%v: %v (%v)", styles.Checkmark, result.Title, result.Answer, timeTaken)))
+	} else {
+		fmt.Println(styles.IncorrectAnswerStyle.Render(fmt.Sprintf("%v%v: got %v, expected %v (%v)", styles.FailureX, result.Title, result.Answer, result.Expected, timeTaken)))
+	}
+}
+
+// Finds the answer from the last solve for a part, or the last solve at all if part is 0.
+// Falls back to the last solve that isn't for any specific part.
+func findResultAnswer(results []aocgo.Result, part int) (string, int, bool) {
+	var unmarked *aocgo.Result
+	for i := len(results) - 1; i >= 0; i-- {
+		result := results[i]
+		if result.Kind != aocgo.SOLVE_RESULT {
+			continue
+		}
+
+		if part == 0 || result.Part == part {
+			return result.Answer, result.Part, true
+		} else if result.Part == 0 && unmarked == nil {
+			unmarked = &results[i]
+		}
+	}
+
+	if unmarked != nil {
+		return unmarked.Answer, 0, true
+	}
+	return "", 0, false
+}
+
+// Finds the first part of a puzzle that doesn't have a star yet
+func getNextPart(user *resources.User, year, day int) int {
+	puzzle, err := resources.LoadOrCreatePuzzle(year, day, user.GetToken())
+	if err != nil {
+		log.Fatal("Unable to load puzzle.", "err", err)
+	}
+
+	if puzzle.AnswerOne == "" {
+		return 1
+	} else if puzzle.AnswerTwo == "" {
+		return 2
+	}
+
+	log.Fatal("You've already gotten both stars for this puzzle.")
+	return 0
+}
+
+// Counts failed tests for a part, including tests that aren't for any specific part
+func countFailedTests(results []aocgo.Result, part int) int {
+	failed := 0
+	for _, result := range results {
+		if result.Kind == aocgo.TEST_RESULT && !result.Passed && (result.Part == 0 || result.Part == part) {
+			failed++
+		}
+	}
+	return failed
+}
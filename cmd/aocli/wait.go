@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"go.dalton.dog/aocgo/internal/api"
+	"go.dalton.dog/aocgo/internal/resources"
+	"go.dalton.dog/aocgo/internal/utils"
+
+	"github.com/charmbracelet/log"
+)
+
+// Random delay added after a puzzle unlocks before fetching it, so everyone waiting doesn't hit the site at the same instant
+const WAIT_MIN_JITTER = 2 * time.Second
+const WAIT_MAX_JITTER = 5 * time.Second
+
+// Number of times to try fetching the puzzle once it unlocks, since the site can be slow to serve it right away
+const WAIT_FETCH_ATTEMPTS = 5
+
+// Time between attempts at fetching the puzzle, which grows with each attempt
+const WAIT_RETRY_DELAY = 5 * time.Second
+
+// Wait shows a countdown until the next puzzle unlocks, then fetches its page and input into the cache.
+// Command: `aocli wait [--new -t template --pad] [--view]`
+// Params:
+//
+//	(Opt) scaffold - Create the day's directory from a template once it's fetched, the same as `aocli new`
+//	(Opt) view     - View the puzzle once it's fetched
+func Wait(user *resources.User, scaffold, view bool, opts NewOptions) {
+	if api.IsOffline() {
+		log.Fatal("Can't wait for a puzzle in offline mode.")
+	}
+
+	year, day, unlock := utils.GetNextUnlock(time.Now())
+	log.Info("Waiting for the next puzzle.", "year", year, "day", day, "unlocks", unlock.Local().Format(time.DateTime))
+
+	status := fmt.Sprintf("%v Day %v unlocks in", year, day)
+	if !RunCountdown(unlock.Add(getJitter()), status) {
+		log.Info("Stopped waiting.")
+		return
+	}
+
+	puzzle, err := fetchUnlockedPuzzle(user, year, day)
+	if err != nil {
+		log.Fatal("Unable to load puzzle.", "year", year, "day", day, "err", err)
+	}
+	log.Info("Puzzle and input loaded!", "year", year, "day", day, "title", puzzle.GetName())
+
+	if scaffold {
+		newDay(user, year, day, opts)
+	}
+
+	if view {
+		puzzle.Display()
+	}
+}
+
+// Fetches a puzzle that just unlocked, along with its input, retrying with a growing delay if it isn't ready yet.
+// Every request still goes through the API client's rate limiter.
+func fetchUnlockedPuzzle(user *resources.User, year, day int) (*resources.Puzzle, error) {
+	var err error
+	for attempt := 1; attempt <= WAIT_FETCH_ATTEMPTS; attempt++ {
+		var puzzle *resources.Puzzle
+		puzzle, err = resources.LoadOrCreatePuzzle(year, day, user.GetToken())
+		if err == nil {
+			return puzzle, nil
+		}
+
+		if attempt == WAIT_FETCH_ATTEMPTS {
+			break
+		}
+
+		delay := time.Duration(attempt)*WAIT_RETRY_DELAY + getJitter()
+		if errors.Is(err, api.ErrRateLimited) {
+			delay *= 2
+		}
+
+		log.Warn("Puzzle isn't ready yet, trying again.", "attempt", attempt, "in", delay.Round(time.Second), "err", err)
+		time.Sleep(delay)
+	}
+
+	return nil, err
+}
+
+// Returns a random delay between WAIT_MIN_JITTER and WAIT_MAX_JITTER
+func getJitter() time.Duration {
+	return WAIT_MIN_JITTER + rand.N(WAIT_MAX_JITTER-WAIT_MIN_JITTER)
+}
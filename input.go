@@ -0,0 +1,108 @@
+package aocgo
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.dalton.dog/aocgo/internal/config"
+	"go.dalton.dog/aocgo/internal/utils"
+
+	"github.com/charmbracelet/log"
+)
+
+// INPUT_ENV is the environment variable that points the input functions at a specific input file.
+// It can also be set by running the solution with "-input <file>".
+const INPUT_ENV = "AOCGO_INPUT"
+
+// INPUT_FLAG is the command line flag that points the input functions at a specific input file
+const INPUT_FLAG = "input"
+
+// Loads input from a local file instead of the cache or site. In order, the input is taken from:
+//   - The file passed with the -input flag, or the AOCGO_INPUT environment variable
+//   - The project's input file, like input.txt, in the working directory or next to the solution's source file
+//
+// Both are only used for the puzzle being solved, not one loaded by an explicit year and day.
+// Returns false if there isn't a local file to use.
+func loadLocalInput(year, day int) ([]byte, bool, error) {
+	curYear, curDay, err := getYearAndDay()
+	if err != nil || curYear != year || curDay != day {
+		return nil, false, nil
+	}
+
+	if path := getInputOverride(); path != "" {
+		log.Debug("Loading input from override", "path", path)
+		input, err := os.ReadFile(path)
+		return input, true, err
+	}
+
+	conf, err := config.Load()
+	if err != nil || conf.Input == "" {
+		return nil, false, err
+	}
+	filename := utils.FormatLayout(conf.Input, year, day)
+
+	for _, dir := range getSolutionDirs() {
+		path := filepath.Join(dir, filename)
+		input, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, false, err
+		}
+
+		log.Debug("Loading input from local file", "path", path)
+		return input, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// Finds the input file passed in with the -input flag, falling back to the AOCGO_INPUT environment variable
+func getInputOverride() string {
+	if path := findInputFlag(os.Args[1:]); path != "" {
+		return path
+	}
+	return strings.TrimSpace(os.Getenv(INPUT_ENV))
+}
+
+// Finds the value of the -input flag in the arguments, as "-input file", "--input file", or "-input=file".
+// Solutions can have flags of their own, so the arguments are searched instead of parsed.
+func findInputFlag(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != INPUT_FLAG {
+			continue
+		}
+
+		if hasValue {
+			return value
+		} else if i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+
+	return ""
+}
+
+// Returns the directories a solution's local files could be in: the working directory, then the source file's directory
+func getSolutionDirs() []string {
+	var dirs []string
+	if cwd, err := os.Getwd(); err == nil {
+		dirs = append(dirs, cwd)
+	}
+
+	if file := findMainFile(); file != "" && filepath.IsAbs(file) {
+		if dir := filepath.Dir(file); len(dirs) == 0 || dir != dirs[0] {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return dirs
+}
@@ -0,0 +1,127 @@
+package aocgo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.dalton.dog/aocgo/internal/api"
+	"go.dalton.dog/aocgo/internal/cache"
+	"go.dalton.dog/aocgo/internal/resources"
+)
+
+func TestFindInputFlag(t *testing.T) {
+	var tests = []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"-input", "alt.txt"}, "alt.txt"},
+		{[]string{"-v", "--input=alt.txt"}, "alt.txt"},
+		{[]string{"--input", "alt.txt", "-v"}, "alt.txt"},
+		{[]string{"-inputs", "alt.txt"}, ""},
+		{[]string{"input", "alt.txt"}, ""},
+		{[]string{"--", "-input", "alt.txt"}, ""},
+		{[]string{"-input"}, ""},
+	}
+
+	for _, test := range tests {
+		if got := findInputFlag(test.args); got != test.expected {
+			t.Errorf("Expected %v from %v, got %q", test.expected, test.args, got)
+		}
+	}
+}
+
+func TestLoadLocalInput(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "2015", "1")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd)
+
+	t.Setenv(YEAR_ENV, "")
+	t.Setenv(DAY_ENV, "")
+	t.Setenv(INPUT_ENV, "")
+
+	if _, ok, err := loadLocalInput(2015, 1); ok || err != nil {
+		t.Fatalf("Expected no local input yet, got %v (err: %v)", ok, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "input.txt"), []byte("local"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	input, ok, err := loadLocalInput(2015, 1)
+	if !ok || err != nil || string(input) != "local" {
+		t.Errorf("Expected the local input file, got %q (err: %v)", input, err)
+	}
+
+	// Other puzzles shouldn't use this directory's input
+	if _, ok, _ := loadLocalInput(2015, 2); ok {
+		t.Errorf("Expected the local input to only be used for 2015/1")
+	}
+
+	altPath := filepath.Join(dir, "alt.txt")
+	if err := os.WriteFile(altPath, []byte("alternate"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(INPUT_ENV, altPath)
+
+	input, ok, err = loadLocalInput(2015, 1)
+	if !ok || err != nil || string(input) != "alternate" {
+		t.Errorf("Expected the input from %v, got %q (err: %v)", INPUT_ENV, input, err)
+	}
+
+	// The override is for the puzzle being solved, not others it loads
+	if _, ok, _ := loadLocalInput(2015, 2); ok {
+		t.Errorf("Expected the override to only be used for 2015/1")
+	}
+
+	t.Setenv(INPUT_ENV, filepath.Join(dir, "missing.txt"))
+	if _, _, err := loadLocalInput(2015, 1); err == nil {
+		t.Errorf("Expected an error for a missing override file")
+	}
+}
+
+func TestGetDataOffline(t *testing.T) {
+	fake, server := startFakeServer(t)
+	t.Setenv(INPUT_ENV, "")
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd)
+
+	// Cache the puzzle's input, but not the user's display name
+	if err := cache.StartupDBM(fake.Token); err != nil {
+		t.Fatal(err)
+	}
+	api.InitClient(fake.Token)
+	puzzle, err := resources.LoadOrCreatePuzzle(2015, 1, fake.Token)
+	if err == nil {
+		_, err = puzzle.GetUserInput()
+	}
+	cache.ShutdownDBM()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server.Close()
+	t.Setenv("AOC_OFFLINE", "true")
+
+	input, err := getData(2015, 1)
+	if err != nil || string(input) != fake.GetPuzzle(2015, 1).Input {
+		t.Errorf("Expected the cached input offline, got %q (err: %v)", input, err)
+	}
+}
@@ -2,112 +2,106 @@ package api
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"net/http"
-	"net/url"
+	"os"
+	"slices"
 	"strconv"
-	"strings"
 	"time"
-
-	"github.com/charmbracelet/log"
-	"golang.org/x/time/rate"
 )
 
 // USER_AGENT is used to identify requests from this program
 const USER_AGENT = "go.dalton.dog/aocgo"
 const BASE_URL = "https://adventofcode.com"
-const YEAR_URL = BASE_URL + "/%v"
-const DAY_URL = YEAR_URL + "/day/%v"
+
+// BASE_URL_ENV is the environment variable that points every client at another server, like the fake one
+const BASE_URL_ENV = "AOC_BASE_URL"
+
+// Default amount of time a single request can take
+const DEFAULT_TIMEOUT = 30 * time.Second
 
 // Number of API requests allowed per second.
 const ONSEASON_REQS_PER_SEC = 10
 const OFFSEASON_REQS_PER_SEC = 25
 
-var MasterClient httpClient
+// MasterClient is used by the package level request functions.
+// It's created by InitClient, but can be replaced entirely, such as in tests.
+var MasterClient *Client
 
-type httpClient struct {
-	client       http.Client
-	sessionToken string // Eventually make this []string in case we want to run for multiple users?
-	rateLimiter  *rate.Limiter
-}
+// ErrOffline is returned instead of making a request while in offline mode
+var ErrOffline = errors.New("Network access is disabled in offline mode")
 
-// Do is a wrapper around a normal client call in order to use our rate limiter.
-func (c *httpClient) Do(req *http.Request) (*http.Response, error) {
-	err := c.rateLimiter.Wait(context.Background())
-	if err != nil {
-		return nil, err
-	}
+// ErrRateLimited is returned when the site responds that too many requests have been made
+var ErrRateLimited = errors.New("Too many requests have been made to the site, try again later")
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
+var offline bool
 
-	return resp, nil
+// SetOffline enables or disables offline mode.
+// While offline, no requests will be made to the site.
+func SetOffline(isOffline bool) {
+	offline = isOffline
 }
 
-// InitClient creates the API client with a given user session token.
-func InitClient(userSessionToken string) {
-	var reqsPerSec int
-	if time.Now().Month() <= time.October && time.Now().Month() >= time.March {
-		reqsPerSec = OFFSEASON_REQS_PER_SEC
-	} else {
-		reqsPerSec = ONSEASON_REQS_PER_SEC
+// IsOffline returns true if offline mode was enabled, either via SetOffline or the AOC_OFFLINE environment variable.
+func IsOffline() bool {
+	if offline {
+		return true
 	}
 
-	limiter := rate.NewLimiter(rate.Every(time.Second/time.Duration(reqsPerSec)), 1)
-	client := httpClient{
-		client:       http.Client{},
-		sessionToken: userSessionToken,
-		rateLimiter:  limiter,
-	}
-	MasterClient = client
+	envOffline, err := strconv.ParseBool(os.Getenv("AOC_OFFLINE"))
+	return err == nil && envOffline
 }
 
-// NewGetReq will make a request of a certain URL on behalf of a given user session token.
-func NewGetReq(url string, sessionToken string) (*http.Response, error) {
-	log.Debug("Making GET request.", "URL", url, "token", sessionToken)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		log.Fatal("Error creating GET request!", "error", err)
-	}
+// DefaultOptions are applied to every client created by InitClient, before any options passed in.
+// Setting these will point everything that uses the master client at another server.
+var DefaultOptions []Option
+
+// InitClient creates the master API client with a given user session token.
+func InitClient(userSessionToken string, opts ...Option) {
+	MasterClient = NewClient(userSessionToken, append(slices.Clone(DefaultOptions), opts...)...)
+}
 
-	if sessionToken == "" {
-		sessionToken = MasterClient.sessionToken
+func getMasterClient() *Client {
+	if MasterClient == nil {
+		InitClient("")
 	}
+	return MasterClient
+}
 
-	req.Header.Add("User-Agent", USER_AGENT)
-	req.Header.Add("Cookie", fmt.Sprintf("session=%s", strings.TrimSpace(sessionToken)))
+// NewGetReq will make a request of a certain path or URL on behalf of a given user session token.
+// If no token is provided, the master client's token is used.
+// It can't be canceled, so use NewGetReqContext to stop it early.
+func NewGetReq(url string, sessionToken string) (*http.Response, error) {
+	return NewGetReqContext(context.Background(), url, sessionToken)
+}
 
-	return MasterClient.Do(req)
+// NewGetReqContext works like NewGetReq, but stops waiting on the rate limit or the site once ctx is done.
+func NewGetReqContext(ctx context.Context, url string, sessionToken string) (*http.Response, error) {
+	return getMasterClient().get(ctx, url, sessionToken)
 }
 
 // SubmitAnswer will submit an answer to a puzzle on behalf of a given user token.
+// It can't be canceled, so use SubmitAnswerContext to stop it early.
 func SubmitAnswer(year int, day int, part int, userSession string, answer string) (*http.Response, error) {
-	URL := puzzleAnswerURL(year, day)
-	log.Debugf("Attempting to submit answer for Day %v (%v) [Part %v] to URL %v", day, year, part, URL)
-	log.Debugf("Answer: %v -- User: %v", answer, userSession)
-
-	formData := url.Values{}
-	formData.Set("level", strconv.Itoa(part))
-	formData.Set("answer", answer)
-
-	encodedForm := formData.Encode()
-
-	req, err := http.NewRequest("POST", URL, strings.NewReader(encodedForm))
-	if err != nil {
-		return nil, err
-	}
+	return SubmitAnswerContext(context.Background(), year, day, part, userSession, answer)
+}
 
-	req.Header.Add("User-Agent", USER_AGENT)
-	req.Header.Add("Cookie", fmt.Sprintf("session=%v", userSession))
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+// SubmitAnswerContext works like SubmitAnswer, but stops waiting on the rate limit or the site once ctx is done.
+func SubmitAnswerContext(ctx context.Context, year int, day int, part int, userSession string, answer string) (*http.Response, error) {
+	return getMasterClient().submitAnswer(ctx, year, day, part, answer, userSession)
+}
 
-	return MasterClient.client.Do(req)
+// URL returns the full URL for a path on the master client's server.
+func URL(path string) string {
+	return getMasterClient().URL(path)
 }
 
-// URL Helper Methods
+// YearURL returns the URL of a year's calendar page on the master client's server.
+func YearURL(year int) string {
+	return getMasterClient().YearURL(year)
+}
 
-func puzzleAnswerURL(year int, day int) string {
-	return fmt.Sprintf(DAY_URL, year, day) + "/answer"
+// DayURL returns the URL of a single day's puzzle page on the master client's server.
+func DayURL(year, day int) string {
+	return getMasterClient().DayURL(year, day)
 }
@@ -0,0 +1,193 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"golang.org/x/time/rate"
+)
+
+// Client makes requests to the site on behalf of a user, respecting a rate limiter.
+type Client struct {
+	client       http.Client
+	baseURL      string
+	userAgent    string
+	sessionToken string // Eventually make this []string in case we want to run for multiple users?
+	rateLimiter  *rate.Limiter
+}
+
+// Option configures a Client when it's created.
+type Option func(*Client)
+
+// WithBaseURL points the client at a different server, such as an httptest.Server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) { c.baseURL = strings.TrimSuffix(baseURL, "/") }
+}
+
+// WithTransport replaces the transport used to make requests.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) { c.client.Transport = transport }
+}
+
+// WithRateLimiter replaces the seasonal rate limiter.
+func WithRateLimiter(limiter *rate.Limiter) Option {
+	return func(c *Client) { c.rateLimiter = limiter }
+}
+
+// WithUserAgent replaces the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) { c.userAgent = userAgent }
+}
+
+// WithTimeout sets how long a single request can take before being cancelled.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) { c.client.Timeout = timeout }
+}
+
+// NewClient creates a client for a given user session token.
+// Without any options, it will talk to the real site with the seasonal rate limit,
+// unless the BASE_URL_ENV environment variable points it somewhere else.
+func NewClient(sessionToken string, opts ...Option) *Client {
+	baseURL := BASE_URL
+	if envURL := os.Getenv(BASE_URL_ENV); envURL != "" {
+		baseURL = strings.TrimSuffix(envURL, "/")
+	}
+
+	c := &Client{
+		client:       http.Client{Timeout: DEFAULT_TIMEOUT},
+		baseURL:      baseURL,
+		userAgent:    USER_AGENT,
+		sessionToken: strings.TrimSpace(sessionToken),
+		rateLimiter:  newSeasonalLimiter(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Creates a rate limiter that's stricter during the event
+func newSeasonalLimiter() *rate.Limiter {
+	var reqsPerSec int
+	if time.Now().Month() <= time.October && time.Now().Month() >= time.March {
+		reqsPerSec = OFFSEASON_REQS_PER_SEC
+	} else {
+		reqsPerSec = ONSEASON_REQS_PER_SEC
+	}
+
+	return rate.NewLimiter(rate.Every(time.Second/time.Duration(reqsPerSec)), 1)
+}
+
+// BaseURL returns the root URL of the server the client talks to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// URL returns the full URL for a path on the client's server. Full URLs are returned as-is.
+func (c *Client) URL(path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return c.baseURL + path
+}
+
+// YearURL returns the URL of a year's calendar page.
+func (c *Client) YearURL(year int) string {
+	return c.URL(fmt.Sprintf("/%v", year))
+}
+
+// DayURL returns the URL of a single day's puzzle page.
+func (c *Client) DayURL(year, day int) string {
+	return c.URL(fmt.Sprintf("/%v/day/%v", year, day))
+}
+
+// Do is a wrapper around a normal client call in order to use our rate limiter.
+func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if IsOffline() {
+		return nil, ErrOffline
+	}
+
+	err := c.rateLimiter.Wait(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		resp.Body.Close()
+		return nil, ErrRateLimited
+	}
+
+	return resp, nil
+}
+
+// Get will make a GET request for a path or URL on behalf of the client's user.
+func (c *Client) Get(ctx context.Context, path string) (*http.Response, error) {
+	return c.get(ctx, path, c.sessionToken)
+}
+
+func (c *Client) get(ctx context.Context, path, sessionToken string) (*http.Response, error) {
+	URL := c.URL(path)
+	log.Debug("Making GET request.", "URL", URL, "token", sessionToken)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.addHeaders(req, sessionToken)
+
+	return c.Do(ctx, req)
+}
+
+// SubmitAnswer will submit an answer to a puzzle on behalf of the client's user.
+func (c *Client) SubmitAnswer(ctx context.Context, year, day, part int, answer string) (*http.Response, error) {
+	return c.submitAnswer(ctx, year, day, part, answer, c.sessionToken)
+}
+
+func (c *Client) submitAnswer(ctx context.Context, year, day, part int, answer, sessionToken string) (*http.Response, error) {
+	URL := c.DayURL(year, day) + "/answer"
+	log.Debugf("Attempting to submit answer for Day %v (%v) [Part %v] to URL %v", day, year, part, URL)
+	log.Debugf("Answer: %v -- User: %v", answer, sessionToken)
+
+	formData := url.Values{}
+	formData.Set("level", strconv.Itoa(part))
+	formData.Set("answer", answer)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", URL, strings.NewReader(formData.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	c.addHeaders(req, sessionToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return c.Do(ctx, req)
+}
+
+func (c *Client) addHeaders(req *http.Request, sessionToken string) {
+	if sessionToken == "" {
+		sessionToken = c.sessionToken
+	}
+
+	req.Header.Add("User-Agent", c.userAgent)
+	req.Header.Add("Cookie", fmt.Sprintf("session=%s", strings.TrimSpace(sessionToken)))
+}
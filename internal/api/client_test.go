@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestClientGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2015/day/1/input" {
+			t.Errorf("Unexpected path %v", r.URL.Path)
+		}
+		if ua := r.Header.Get("User-Agent"); ua != "test agent" {
+			t.Errorf("Expected user agent 'test agent', got %v", ua)
+		}
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "test_token" {
+			t.Errorf("Expected session cookie 'test_token', got %v (err: %v)", cookie, err)
+		}
+		io.WriteString(w, "input data")
+	}))
+	defer server.Close()
+
+	client := NewClient(" test_token\n", WithBaseURL(server.URL+"/"), WithUserAgent("test agent"))
+
+	resp, err := client.Get(context.Background(), client.DayURL(2015, 1)+"/input")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "input data" {
+		t.Errorf("Expected body 'input data', got %v", string(body))
+	}
+}
+
+func TestClientSubmitAnswer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/2020/day/5/answer" {
+			t.Errorf("Unexpected request %v %v", r.Method, r.URL.Path)
+		}
+		r.ParseForm()
+		if r.PostForm.Get("level") != "2" || r.PostForm.Get("answer") != "1234" {
+			t.Errorf("Unexpected form data %v", r.PostForm)
+		}
+	}))
+	defer server.Close()
+
+	// Only allow a single request, so a second one has to wait on the limiter
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 1)
+	client := NewClient("test_token", WithBaseURL(server.URL), WithRateLimiter(limiter))
+
+	resp, err := client.SubmitAnswer(context.Background(), 2020, 5, 2, "1234")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.SubmitAnswer(ctx, 2020, 5, 2, "1234"); err == nil {
+		t.Errorf("Expected submission to be held up by the rate limiter")
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token", WithBaseURL(server.URL))
+
+	if _, err := client.Get(context.Background(), "/"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited, got %v", err)
+	}
+
+	SetOffline(true)
+	defer SetOffline(false)
+
+	if _, err := client.Get(context.Background(), "/"); !errors.Is(err, ErrOffline) {
+		t.Errorf("Expected ErrOffline, got %v", err)
+	}
+}
+
+func TestInitClientKeepsDefaultOptions(t *testing.T) {
+	backup := DefaultOptions
+	t.Cleanup(func() {
+		DefaultOptions = backup
+		MasterClient = nil
+	})
+
+	// Spare capacity is where appending to DefaultOptions would write the options passed in
+	DefaultOptions = make([]Option, 1, 4)
+	DefaultOptions[0] = WithUserAgent("default agent")
+
+	InitClient("", WithUserAgent("first agent"))
+	InitClient("", WithBaseURL("http://localhost"))
+
+	if spare := DefaultOptions[:cap(DefaultOptions)]; spare[1] != nil {
+		t.Errorf("Expected InitClient to leave DefaultOptions' backing array alone")
+	}
+	if MasterClient.userAgent != "default agent" {
+		t.Errorf("Expected the first client's options to not leak into the next, got user agent %v", MasterClient.userAgent)
+	}
+}
+
+func TestPackageRequestsContext(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	MasterClient = NewClient("test_token", WithBaseURL(server.URL))
+	t.Cleanup(func() { MasterClient = nil })
+
+	resp, err := NewGetReqContext(context.Background(), "/2015/day/1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := NewGetReqContext(ctx, "/2015/day/1", ""); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a canceled request, got %v", err)
+	}
+	if _, err := SubmitAnswerContext(ctx, 2015, 1, 1, "", "5"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a canceled submission, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected only the first request to reach the server, got %v", requests)
+	}
+}
@@ -1,12 +1,16 @@
 package cache
 
 import (
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"os"
 	"path"
+	"slices"
 	"time"
 
 	"github.com/charmbracelet/log"
+	"go.dalton.dog/aocgo/internal/api"
 	bolt "go.etcd.io/bbolt"
 )
 
@@ -17,15 +21,42 @@ const (
 	USER_DATA    = "UserData"
 	LEADERBOARDS = "Leaderboards"
 	PUZZLES      = "Puzzles"
+	BENCHMARKS   = "Benchmarks"
 
 	// Sub Buckets
-	USER_INPUTS = "UserInputs"
-	USER_PAGES  = "UserPages"
+	USER_INPUTS   = "UserInputs"
+	USER_PAGES    = "UserPages"
+	RESOURCE_META = "ResourceMeta" // Nested in every bucket, stores TTL metadata keyed by resource ID
+
+	// Resource types that don't have a bucket of their own
+	PRIVATE_LEADERBOARD = "PrivateLeaderboard"
 
 	// Other
 	GENERIC_USER = "GenericUser"
+	DISPLAY_NAME = "DisplayName"
 )
 
+// The site asks that private leaderboard JSON be requested no more than once every 15 minutes
+const MIN_PRIVATE_LB_TTL = 15 * time.Minute
+
+// Every top level bucket that gets created on startup
+var buckets = []string{PAGE_DATA, CALENDAR, PUZZLES, USER_INPUTS, USER_DATA, LEADERBOARDS, BENCHMARKS}
+
+// Resource types that can be given a TTL. These are safe to load again from the site, unlike puzzles and inputs,
+// which hold things like submissions that the site won't give back.
+var refreshableTypes = []string{CALENDAR, LEADERBOARDS, PRIVATE_LEADERBOARD}
+
+// Time-to-live for each resource type. Resource types default to their bucket name.
+// Types that aren't in here (or are 0) never expire. aocli changes these with the project config's [cache.ttl] table.
+var resourceTTLs = map[string]time.Duration{
+	CALENDAR:            15 * time.Minute,
+	LEADERBOARDS:        15 * time.Minute,
+	PRIVATE_LEADERBOARD: MIN_PRIVATE_LB_TTL,
+}
+
+// Swappable for testing
+var timeNow = time.Now
+
 var (
 	UserCacheDir, _ = os.UserCacheDir()
 	CacheDir        = path.Join(UserCacheDir, "aocgo")
@@ -42,6 +73,74 @@ type Resource interface {
 	SaveResource()
 }
 
+// ExpiringResource is a resource whose TTL is looked up by something other than its bucket name
+type ExpiringResource interface {
+	Resource
+	GetResourceType() string
+}
+
+// NotCachedError is returned when a resource is needed from the cache, but it hasn't been stored yet
+type NotCachedError struct {
+	Bucket string
+	ID     string
+}
+
+func (e *NotCachedError) Error() string {
+	return fmt.Sprintf("Resource %v hasn't been cached in %v yet", e.ID, e.Bucket)
+}
+
+// Metadata stored alongside each resource so it can be expired
+type resourceMeta struct {
+	Saved time.Time
+	TTL   time.Duration
+
+	// Resource type, so a TTL changed since the resource was saved still applies to it.
+	// Empty for resources saved by older versions, which use the stored TTL.
+	Type string
+}
+
+// Returns true if the resource should be reloaded from the site
+func (m *resourceMeta) isExpired() bool {
+	ttl := m.TTL
+	if m.Type != "" {
+		ttl = GetTTL(m.Type)
+	}
+	return ttl > 0 && timeNow().After(m.Saved.Add(ttl))
+}
+
+// SetTTL configures how long a resource type stays fresh after being saved.
+// Only the types from ResourceTypes can be set, and private leaderboards can never be set below MIN_PRIVATE_LB_TTL.
+func SetTTL(resourceType string, ttl time.Duration) error {
+	if !slices.Contains(refreshableTypes, resourceType) {
+		return fmt.Errorf("resource type %v can't be given a TTL", resourceType)
+	}
+
+	if resourceType == PRIVATE_LEADERBOARD && ttl < MIN_PRIVATE_LB_TTL {
+		log.Warn("Private leaderboards can't be refreshed more than once every 15 minutes.", "requested", ttl)
+		ttl = MIN_PRIVATE_LB_TTL
+	}
+
+	resourceTTLs[resourceType] = ttl
+	return nil
+}
+
+// GetTTL returns how long a resource type stays fresh after being saved
+func GetTTL(resourceType string) time.Duration {
+	return resourceTTLs[resourceType]
+}
+
+// ResourceTypes returns every resource type that can be given a TTL
+func ResourceTypes() []string {
+	return slices.Clone(refreshableTypes)
+}
+
+func getResourceType(r Resource) string {
+	if er, ok := r.(ExpiringResource); ok {
+		return er.GetResourceType()
+	}
+	return r.GetBucketName()
+}
+
 var masterDBM *DatabaseManager
 
 // Create and initialize master database manager, taking in a valid AoC user session token
@@ -67,7 +166,7 @@ func (dbm *DatabaseManager) initializeDBM(userSession string) error {
 	// log.Debug("---Initializing Database---")
 
 	// Load save file path and ensure it exists
-	dbm.saveFilePath = fmt.Sprintf(CacheFile, userSession)
+	dbm.saveFilePath = cacheFilePath(userSession)
 	os.MkdirAll(path.Join(CacheDir), os.ModePerm)
 
 	// log.Debugf("Trying to access save file path: %v", dbm.saveFilePath)
@@ -98,11 +197,17 @@ func (dbm *DatabaseManager) initializeDBM(userSession string) error {
 // Ensure all buckets exist so they can assuredly be loaded later on
 func (dbm *DatabaseManager) initializeBuckets() {
 	dbm.sessionDB.Update(func(tx *bolt.Tx) error {
-		tx.CreateBucketIfNotExists([]byte(PAGE_DATA))
-		tx.CreateBucketIfNotExists([]byte(PUZZLES))
-		tx.CreateBucketIfNotExists([]byte(USER_INPUTS))
-		tx.CreateBucketIfNotExists([]byte(USER_DATA))
-		tx.CreateBucketIfNotExists([]byte(LEADERBOARDS))
+		for _, name := range buckets {
+			bucket, err := tx.CreateBucketIfNotExists([]byte(name))
+			if err != nil {
+				return err
+			}
+
+			_, err = bucket.CreateBucketIfNotExists([]byte(RESOURCE_META))
+			if err != nil {
+				return err
+			}
+		}
 		return nil
 	})
 
@@ -131,23 +236,40 @@ func SaveResource(r Resource) {
 		if err != nil {
 			return err
 		}
+
+		resourceType := getResourceType(r)
+		metaData, err := json.Marshal(resourceMeta{Saved: timeNow(), TTL: GetTTL(resourceType), Type: resourceType})
+		if err != nil {
+			return err
+		}
+
 		bucket.Put([]byte(r.GetID()), resourceData)
+		bucket.Bucket([]byte(RESOURCE_META)).Put([]byte(r.GetID()), metaData)
 		return nil
 	})
 }
 
-// Save resource to database
+// Save resource to database. Its resource type is the bucket name, which sets its TTL.
 func SaveGenericResource(bucketName, idToSave string, dataToSave []byte) {
+	if masterDBM == nil {
+		return
+	}
 	// log.Debug("Saving resource", "bucket", bucketName, "id", idToSave, "data", dataToSave)
 	masterDBM.sessionDB.Update(func(tx *bolt.Tx) error {
+		metaData, err := json.Marshal(resourceMeta{Saved: timeNow(), TTL: GetTTL(bucketName), Type: bucketName})
+		if err != nil {
+			return err
+		}
+
 		bucket := tx.Bucket([]byte(bucketName))
 		bucket.Put([]byte(idToSave), dataToSave)
+		bucket.Bucket([]byte(RESOURCE_META)).Put([]byte(idToSave), metaData)
 		return nil
 	})
-
 }
 
-// Load resource from database by ID
+// Load resource from database by ID.
+// Returns nil if the resource has been stored longer than its TTL.
 func LoadResource(bucketName, idToLoad string) []byte {
 	if masterDBM == nil {
 		return nil
@@ -156,6 +278,17 @@ func LoadResource(bucketName, idToLoad string) []byte {
 	var output []byte
 	masterDBM.sessionDB.View(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket([]byte(bucketName))
+
+		if metaBucket := bucket.Bucket([]byte(RESOURCE_META)); metaBucket != nil {
+			if metaData := metaBucket.Get([]byte(idToLoad)); metaData != nil {
+				var meta resourceMeta
+				if err := json.Unmarshal(metaData, &meta); err == nil && meta.isExpired() {
+					log.Debug("Stored resource expired", "bucket", bucketName, "id", idToLoad, "saved", meta.Saved)
+					return nil
+				}
+			}
+		}
+
 		output = bucket.Get([]byte(idToLoad))
 		return nil
 	})
@@ -163,9 +296,36 @@ func LoadResource(bucketName, idToLoad string) []byte {
 	return output
 }
 
+// Load resource from database by ID, even if it's been stored longer than its TTL
+func LoadStaleResource(bucketName, idToLoad string) []byte {
+	if masterDBM == nil {
+		return nil
+	}
+
+	var output []byte
+	masterDBM.sessionDB.View(func(tx *bolt.Tx) error {
+		output = tx.Bucket([]byte(bucketName)).Get([]byte(idToLoad))
+		return nil
+	})
+	return output
+}
+
 // Clear database file for a certain user
 func ClearUserDatabase(sessionToken string) {
-	os.Remove(fmt.Sprintf(CacheFile, sessionToken))
+	os.Remove(cacheFilePath(sessionToken))
+}
+
+// Returns the database file for a certain user. Servers other than the real site, like the fake one,
+// get their own file per URL so their resources never mix with the site's.
+func cacheFilePath(sessionToken string) string {
+	baseURL := os.Getenv(api.BASE_URL_ENV)
+	if baseURL == "" {
+		return fmt.Sprintf(CacheFile, sessionToken)
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(baseURL))
+	return fmt.Sprintf(CacheFile, fmt.Sprintf("%v-%08x", sessionToken, hash.Sum32()))
 }
 
 func checkErr(err error) {
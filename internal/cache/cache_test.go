@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.dalton.dog/aocgo/internal/api"
+)
+
+type testResource struct {
+	id           string
+	bucket       string
+	resourceType string
+}
+
+func (r *testResource) GetID() string                { return r.id }
+func (r *testResource) GetBucketName() string        { return r.bucket }
+func (r *testResource) MarshalData() ([]byte, error) { return []byte(r.id), nil }
+func (r *testResource) SaveResource()                { SaveResource(r) }
+
+type testExpiringResource struct {
+	testResource
+}
+
+func (r *testExpiringResource) GetResourceType() string { return r.resourceType }
+
+func startTestDBM(t *testing.T) {
+	backupCacheFile := CacheFile
+	CacheFile = filepath.Join(t.TempDir(), "%v.db")
+
+	if err := StartupDBM("test_token"); err != nil {
+		t.Fatalf("Unable to start database: %v", err)
+	}
+
+	t.Cleanup(func() {
+		ShutdownDBM()
+		CacheFile = backupCacheFile
+		timeNow = time.Now
+	})
+}
+
+func TestLoadResourceTTL(t *testing.T) {
+	startTestDBM(t)
+
+	start := time.Now()
+	timeNow = func() time.Time { return start }
+
+	SaveResource(&testResource{id: "puzzle", bucket: PUZZLES})
+	SaveResource(&testResource{id: "leaderboard", bucket: LEADERBOARDS})
+	SaveResource(&testExpiringResource{testResource{id: "private", bucket: LEADERBOARDS, resourceType: PRIVATE_LEADERBOARD}})
+
+	var tests = []struct {
+		bucket, id string
+		after      time.Duration
+		expectData bool
+	}{
+		{PUZZLES, "puzzle", 24 * time.Hour, true},
+		{LEADERBOARDS, "leaderboard", 14 * time.Minute, true},
+		{LEADERBOARDS, "leaderboard", 16 * time.Minute, false},
+		{LEADERBOARDS, "private", 14 * time.Minute, true},
+		{LEADERBOARDS, "private", 16 * time.Minute, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.id+" after "+test.after.String(), func(t *testing.T) {
+			timeNow = func() time.Time { return start.Add(test.after) }
+			data := LoadResource(test.bucket, test.id)
+			if test.expectData && string(data) != test.id {
+				t.Errorf("Expected %v, got %v", test.id, string(data))
+			} else if !test.expectData && data != nil {
+				t.Errorf("Expected resource to be expired, got %v", string(data))
+			}
+		})
+	}
+}
+
+func TestSetTTLPrivateMinimum(t *testing.T) {
+	backup := GetTTL(PRIVATE_LEADERBOARD)
+	defer func() { resourceTTLs[PRIVATE_LEADERBOARD] = backup }()
+
+	SetTTL(PRIVATE_LEADERBOARD, time.Minute)
+	if ttl := GetTTL(PRIVATE_LEADERBOARD); ttl != MIN_PRIVATE_LB_TTL {
+		t.Errorf("Expected TTL to be clamped to %v, got %v", MIN_PRIVATE_LB_TTL, ttl)
+	}
+
+	SetTTL(PRIVATE_LEADERBOARD, time.Hour)
+	if ttl := GetTTL(PRIVATE_LEADERBOARD); ttl != time.Hour {
+		t.Errorf("Expected TTL of %v, got %v", time.Hour, ttl)
+	}
+}
+
+func TestTTLChangesApplyToStoredResources(t *testing.T) {
+	startTestDBM(t)
+
+	backup := GetTTL(USER_DATA)
+	defer func() { resourceTTLs[USER_DATA] = backup }()
+
+	start := time.Now()
+	timeNow = func() time.Time { return start }
+
+	SaveResource(&testResource{id: "leaderboard", bucket: LEADERBOARDS})
+	SaveGenericResource(USER_DATA, "name", []byte("name"))
+
+	timeNow = func() time.Time { return start.Add(time.Hour) }
+	if data := LoadResource(USER_DATA, "name"); string(data) != "name" {
+		t.Errorf("Expected generic resources to never expire by default, got %v", string(data))
+	}
+
+	resourceTTLs[USER_DATA] = 30 * time.Minute
+	if data := LoadResource(USER_DATA, "name"); data != nil {
+		t.Errorf("Expected generic resource to expire with its bucket's TTL, got %v", string(data))
+	}
+
+	backupLB := GetTTL(LEADERBOARDS)
+	defer func() { resourceTTLs[LEADERBOARDS] = backupLB }()
+
+	if err := SetTTL(LEADERBOARDS, 2*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if data := LoadResource(LEADERBOARDS, "leaderboard"); string(data) != "leaderboard" {
+		t.Errorf("Expected a longer TTL to apply to a stored resource, got %v", string(data))
+	}
+}
+
+func TestSetTTLOnlyRefreshable(t *testing.T) {
+	for _, resourceType := range []string{PUZZLES, USER_INPUTS, USER_DATA, PAGE_DATA, BENCHMARKS} {
+		if err := SetTTL(resourceType, time.Hour); err == nil {
+			t.Errorf("Expected %v to be rejected", resourceType)
+		}
+		if ttl := GetTTL(resourceType); ttl != 0 {
+			t.Errorf("Expected %v to never expire, got %v", resourceType, ttl)
+		}
+	}
+}
+
+func TestCacheFilePathPerBaseURL(t *testing.T) {
+	t.Setenv(api.BASE_URL_ENV, "")
+	site := cacheFilePath("token")
+	if site != fmt.Sprintf(CacheFile, "token") {
+		t.Errorf("Expected the real site to use the token's file, got %v", site)
+	}
+
+	t.Setenv(api.BASE_URL_ENV, "http://localhost:8080")
+	fake := cacheFilePath("token")
+	t.Setenv(api.BASE_URL_ENV, "http://localhost:9090")
+	other := cacheFilePath("token")
+
+	if fake == site || other == site || fake == other {
+		t.Errorf("Expected a separate file for each server, got %v, %v, and %v", site, fake, other)
+	}
+}
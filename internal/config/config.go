@@ -0,0 +1,190 @@
+// Package config loads the project configuration file, found by walking up from the working directory
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// CONFIG_FILENAME is the name of the project configuration file
+const CONFIG_FILENAME = ".aocli.toml"
+
+// Config files are looked for with each of these names, in order, in every directory
+var configFilenames = []string{CONFIG_FILENAME, ".aocli.yaml", ".aocli.yml"}
+
+// Defaults for settings that aren't in the config file
+const (
+	DEFAULT_INPUT_FILENAME = "input.txt"
+	DEFAULT_TEMPLATE       = "go"
+	DEFAULT_BASE_FILENAME  = "base.go"
+	DEFAULT_OUT_FILENAME   = "main.go"
+	DEFAULT_RUN_CMD        = "go run ."
+)
+
+// Config is the configuration for a project of puzzle solutions
+type Config struct {
+	// Layout of puzzle directories relative to the config file, like "{year}/day{day:02}".
+	// If empty, the day's directory is inside of the year's directory.
+	Layout string `toml:"layout" yaml:"layout"`
+
+	// Name of the input file next to each solution, like "input.txt". Can use {year} and {day} like the layout.
+	Input string `toml:"input" yaml:"input"`
+
+	New         NewConfig         `toml:"new" yaml:"new"`
+	Run         RunConfig         `toml:"run" yaml:"run"`
+	Leaderboard LeaderboardConfig `toml:"leaderboard" yaml:"leaderboard"`
+	Submit      SubmitConfig      `toml:"submit" yaml:"submit"`
+	Cache       CacheConfig       `toml:"cache" yaml:"cache"`
+
+	// Path of the file the config was loaded from. Empty if there wasn't one.
+	Path string `toml:"-" yaml:"-"`
+}
+
+// NewConfig is the configuration for `aocli new`
+type NewConfig struct {
+	// Name of the template used for each new day, like "go", "python", or "rust"
+	Template string `toml:"template" yaml:"template"`
+
+	// Directory of custom templates relative to the config file, with a directory for each template
+	Templates string `toml:"templates" yaml:"templates"`
+
+	// Zero-pad the day in new directories, like 2023/05. Ignored if there's a layout.
+	Pad bool `toml:"pad" yaml:"pad"`
+
+	// Single file that's used instead of a template if it exists, for projects that only need one file
+	Base string `toml:"base" yaml:"base"`
+
+	// Name the base file is given in each new day's directory
+	Out string `toml:"out" yaml:"out"`
+}
+
+// RunConfig is the configuration for `aocli run` and `aocli bench`
+type RunConfig struct {
+	// Command that runs a solution, like "go run ." or "python3 main.py"
+	Command string `toml:"command" yaml:"command"`
+}
+
+// LeaderboardConfig is the configuration for `aocli leaderboard`
+type LeaderboardConfig struct {
+	// ID of the private leaderboard shown by default, instead of the global one
+	Private string `toml:"private" yaml:"private"`
+}
+
+// SubmitConfig has safety settings for submitting answers
+type SubmitConfig struct {
+	// Ask before any answer is submitted
+	Confirm bool `toml:"confirm" yaml:"confirm"`
+
+	// Wait out any lockout, then submit automatically
+	Wait bool `toml:"wait" yaml:"wait"`
+
+	// Let `aocli run --submit` submit an answer even if some of the solution's tests failed
+	AllowFailedTests bool `toml:"allow_failed_tests" yaml:"allow_failed_tests"`
+}
+
+// CacheConfig is the configuration for how long cached resources stay fresh
+type CacheConfig struct {
+	// How long each resource type is cached for before being loaded again, like Leaderboards = "30m".
+	// Only "Calendar", "Leaderboards", and "PrivateLeaderboard" can be set, and "0s" never expires.
+	TTL map[string]string `toml:"ttl" yaml:"ttl"`
+}
+
+// Default returns the config used when there isn't a config file.
+// Settings that aren't in a config file keep these values.
+func Default() *Config {
+	return &Config{
+		Input: DEFAULT_INPUT_FILENAME,
+		New: NewConfig{
+			Template: DEFAULT_TEMPLATE,
+			Base:     DEFAULT_BASE_FILENAME,
+			Out:      DEFAULT_OUT_FILENAME,
+		},
+		Run: RunConfig{
+			Command: DEFAULT_RUN_CMD,
+		},
+	}
+}
+
+// Root returns the directory the config file is in, which layouts are relative to
+func (c *Config) Root() string {
+	if c.Path == "" {
+		return ""
+	}
+	return filepath.Dir(c.Path)
+}
+
+// Encode returns the config in TOML format, the same way it would be written in a config file
+func (c *Config) Encode() (string, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(c); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Load will load the config file from the working directory or the closest parent directory that has one.
+// If there isn't a config file, the default config is returned.
+func Load() (*Config, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	path, ok := Find(cwd)
+	if !ok {
+		return Default(), nil
+	}
+
+	return LoadFile(path)
+}
+
+// LoadFile will load a config from a specific file. Files ending in .yaml or .yml are read as YAML, and anything else as TOML.
+func LoadFile(path string) (*Config, error) {
+	config := Default()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(data, config); err != nil {
+			return nil, err
+		}
+	default:
+		if _, err := toml.DecodeFile(path, config); err != nil {
+			return nil, err
+		}
+	}
+
+	config.Path = path
+	return config, nil
+}
+
+// Find walks up from dir until it finds a config file. Returns false if there isn't one.
+func Find(dir string) (string, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		for _, name := range configFilenames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, true
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
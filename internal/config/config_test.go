@@ -0,0 +1,101 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindAndLoad(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "2023", "day05")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := Find(nested); ok {
+		t.Fatalf("Expected no config file to be found yet")
+	}
+
+	configPath := filepath.Join(root, CONFIG_FILENAME)
+	if err := os.WriteFile(configPath, []byte(`layout = "{year}/day{day:02}"`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	path, ok := Find(nested)
+	if !ok || path != configPath {
+		t.Fatalf("Expected to find %v, got %v", configPath, path)
+	}
+
+	config, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Layout != "{year}/day{day:02}" || config.Root() != root {
+		t.Errorf("Config wasn't loaded correctly, got %+v", config)
+	}
+}
+
+func TestLoadFileDefaults(t *testing.T) {
+	dir := t.TempDir()
+
+	tomlPath := filepath.Join(dir, CONFIG_FILENAME)
+	tomlData := "[run]\ncommand = \"python3 main.py\"\n\n[submit]\nconfirm = true\n"
+	if err := os.WriteFile(tomlPath, []byte(tomlData), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadFile(tomlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Run.Command != "python3 main.py" || !config.Submit.Confirm {
+		t.Errorf("Expected settings from the file, got %+v", config)
+	}
+	if config.Input != DEFAULT_INPUT_FILENAME || config.New.Base != DEFAULT_BASE_FILENAME {
+		t.Errorf("Expected defaults for settings not in the file, got %+v", config)
+	}
+}
+
+func TestLoadFileYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".aocli.yaml")
+	yamlData := "layout: \"{year}/{day}\"\nleaderboard:\n  private: \"12345\"\nsubmit:\n  allow_failed_tests: true\n"
+	if err := os.WriteFile(path, []byte(yamlData), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if found, ok := Find(filepath.Dir(path)); !ok || found != path {
+		t.Fatalf("Expected to find %v, got %v", path, found)
+	}
+
+	config, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Layout != "{year}/{day}" || config.Leaderboard.Private != "12345" || !config.Submit.AllowFailedTests {
+		t.Errorf("Expected settings from the file, got %+v", config)
+	}
+	if config.Run.Command != DEFAULT_RUN_CMD {
+		t.Errorf("Expected the default run command, got %v", config.Run.Command)
+	}
+}
+
+func TestLoadFileCacheTTL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), CONFIG_FILENAME)
+	tomlData := "[cache.ttl]\nLeaderboards = \"30m\"\nCalendar = \"1h\"\n"
+	if err := os.WriteFile(path, []byte(tomlData), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Cache.TTL["Leaderboards"] != "30m" || config.Cache.TTL["Calendar"] != "1h" {
+		t.Errorf("Expected TTLs from the file, got %+v", config.Cache.TTL)
+	}
+}
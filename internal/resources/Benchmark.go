@@ -0,0 +1,208 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"go.dalton.dog/aocgo/internal/cache"
+	"go.dalton.dog/aocgo/internal/styles"
+	"go.dalton.dog/aocgo/internal/utils"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/charmbracelet/log"
+)
+
+// Number of benchmarks kept for each part of a puzzle. The oldest are dropped first.
+const MAX_BENCHMARK_HISTORY = 50
+
+// BenchmarkStats summarizes the runtimes and allocations from benchmarking a solution
+type BenchmarkStats struct {
+	When time.Time `json:"when"`
+	Runs int       `json:"runs"`
+
+	Min    time.Duration `json:"min"`
+	Median time.Duration `json:"median"`
+	Mean   time.Duration `json:"mean"`
+	P95    time.Duration `json:"p95"`
+
+	AllocsPerRun uint64 `json:"allocsPerRun"`
+	BytesPerRun  uint64 `json:"bytesPerRun"`
+}
+
+// NewBenchmarkStats calculates the stats for a set of runtimes.
+// Allocs and bytes are totals across every run, as reported by runtime.MemStats.
+func NewBenchmarkStats(runtimes []time.Duration, allocs, bytes uint64) BenchmarkStats {
+	stats := BenchmarkStats{
+		When: time.Now(),
+		Runs: len(runtimes),
+	}
+	if len(runtimes) == 0 {
+		return stats
+	}
+
+	sorted := slices.Clone(runtimes)
+	slices.Sort(sorted)
+
+	var total time.Duration
+	for _, runtime := range sorted {
+		total += runtime
+	}
+
+	stats.Min = sorted[0]
+	stats.Mean = total / time.Duration(len(sorted))
+	stats.P95 = sorted[(len(sorted)*95+99)/100-1]
+	if len(sorted)%2 == 1 {
+		stats.Median = sorted[len(sorted)/2]
+	} else {
+		stats.Median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+
+	stats.AllocsPerRun = allocs / uint64(len(runtimes))
+	stats.BytesPerRun = bytes / uint64(len(runtimes))
+
+	return stats
+}
+
+// MedianChange returns how much the median runtime changed from a previous benchmark, as a fraction.
+// Positive values are slower than before.
+func (s BenchmarkStats) MedianChange(previous BenchmarkStats) float64 {
+	if previous.Median == 0 {
+		return 0
+	}
+	return float64(s.Median-previous.Median) / float64(previous.Median)
+}
+
+// BenchmarkHistory is every stored benchmark for a single part of a puzzle, oldest first
+type BenchmarkHistory struct {
+	Year       int
+	Day        int
+	Part       int
+	Benchmarks []BenchmarkStats
+}
+
+func (h *BenchmarkHistory) GetID() string                { return getBenchmarkBucketID(h.Year, h.Day, h.Part) }
+func (h *BenchmarkHistory) GetBucketName() string        { return cache.BENCHMARKS }
+func (h *BenchmarkHistory) MarshalData() ([]byte, error) { return json.Marshal(h) }
+func (h *BenchmarkHistory) SaveResource()                { cache.SaveResource(h) }
+
+// LoadBenchmarkHistory will load the stored benchmarks for a part of a puzzle.
+// Part 0 is for solutions that didn't say which part they solve.
+func LoadBenchmarkHistory(year, day, part int) *BenchmarkHistory {
+	history := &BenchmarkHistory{Year: year, Day: day, Part: part}
+
+	data := cache.LoadStaleResource(cache.BENCHMARKS, history.GetID())
+	if data != nil {
+		if err := json.Unmarshal(data, history); err != nil {
+			log.Warn("Unable to read stored benchmarks, starting fresh.", "err", err)
+			history.Benchmarks = nil
+		}
+	}
+
+	return history
+}
+
+// Latest returns the most recent benchmark, if there is one
+func (h *BenchmarkHistory) Latest() (BenchmarkStats, bool) {
+	if len(h.Benchmarks) == 0 {
+		return BenchmarkStats{}, false
+	}
+	return h.Benchmarks[len(h.Benchmarks)-1], true
+}
+
+// AddBenchmark stores a new benchmark, dropping the oldest ones past MAX_BENCHMARK_HISTORY
+func (h *BenchmarkHistory) AddBenchmark(stats BenchmarkStats) {
+	h.Benchmarks = append(h.Benchmarks, stats)
+	if len(h.Benchmarks) > MAX_BENCHMARK_HISTORY {
+		h.Benchmarks = h.Benchmarks[len(h.Benchmarks)-MAX_BENCHMARK_HISTORY:]
+	}
+	h.SaveResource()
+}
+
+// GetBenchmarkTitle will get the appropriate viewport title for a puzzle's benchmarks
+func GetBenchmarkTitle(year, day int) string {
+	return fmt.Sprintf("Benchmarks -- Year: %d, Day: %d", year, day)
+}
+
+// GetBenchmarkContent will get a table of every stored benchmark for both parts of a puzzle
+func GetBenchmarkContent(year, day int) string {
+	t := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
+		Headers("Part", "Benchmarked", "Runs", "Min", "Median", "Mean", "p95", "Allocs", "Memory", "Change").
+		StyleFunc(styles.GetBenchmarkStyle)
+
+	rows := 0
+	for _, part := range []int{0, 1, 2} {
+		history := LoadBenchmarkHistory(year, day, part)
+
+		partStr := strconv.Itoa(part)
+		if part == 0 {
+			partStr = "?"
+		}
+
+		for i, stats := range history.Benchmarks {
+			change := "-"
+			if i > 0 {
+				change = FormatMedianChange(stats.MedianChange(history.Benchmarks[i-1]))
+			}
+
+			t.Row(partStr, formatSubmitTime(stats.When), strconv.Itoa(stats.Runs),
+				FormatRuntime(stats.Min), FormatRuntime(stats.Median), FormatRuntime(stats.Mean), FormatRuntime(stats.P95),
+				strconv.FormatUint(stats.AllocsPerRun, 10), FormatBytes(stats.BytesPerRun), change)
+			rows++
+		}
+	}
+
+	if rows == 0 {
+		return "No benchmarks have been stored for this puzzle."
+	}
+
+	return t.Render()
+}
+
+// FormatRuntime rounds a runtime to a readable precision
+func FormatRuntime(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(time.Microsecond).String()
+	default:
+		return d.String()
+	}
+}
+
+// FormatBytes formats a number of bytes like "1.5 MiB"
+func FormatBytes(bytes uint64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	div, exp := uint64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// FormatMedianChange formats a change from MedianChange, colored red if it's slower and green if it's faster
+func FormatMedianChange(change float64) string {
+	str := fmt.Sprintf("%+.1f%%", change*100)
+	if change > 0 {
+		return styles.IncorrectAnswerStyle.Render(str)
+	} else if change < 0 {
+		return styles.CorrectAnswerStyle.Render(str)
+	}
+	return str
+}
+
+func getBenchmarkBucketID(year, day, part int) string {
+	return fmt.Sprintf("%v-%v", utils.GetResouceBucketID(year, day), part)
+}
@@ -0,0 +1,77 @@
+package resources
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewBenchmarkStats(t *testing.T) {
+	var runtimes []time.Duration
+	for i := 20; i >= 1; i-- {
+		runtimes = append(runtimes, time.Duration(i)*time.Millisecond)
+	}
+
+	stats := NewBenchmarkStats(runtimes, 200, 4000)
+	if stats.Runs != 20 {
+		t.Errorf("Expected 20 runs, got %v", stats.Runs)
+	}
+	if stats.Min != time.Millisecond {
+		t.Errorf("Expected min of 1ms, got %v", stats.Min)
+	}
+	if stats.Median != 10500*time.Microsecond {
+		t.Errorf("Expected median of 10.5ms, got %v", stats.Median)
+	}
+	if stats.Mean != 10500*time.Microsecond {
+		t.Errorf("Expected mean of 10.5ms, got %v", stats.Mean)
+	}
+	if stats.P95 != 19*time.Millisecond {
+		t.Errorf("Expected p95 of 19ms, got %v", stats.P95)
+	}
+	if stats.AllocsPerRun != 10 || stats.BytesPerRun != 200 {
+		t.Errorf("Expected 10 allocs and 200 bytes per run, got %v and %v", stats.AllocsPerRun, stats.BytesPerRun)
+	}
+
+	if runtimes[0] != 20*time.Millisecond {
+		t.Error("Runtimes passed in shouldn't be reordered")
+	}
+}
+
+func TestNewBenchmarkStatsSingleRun(t *testing.T) {
+	stats := NewBenchmarkStats([]time.Duration{time.Second}, 0, 0)
+	if stats.Min != time.Second || stats.Median != time.Second || stats.Mean != time.Second || stats.P95 != time.Second {
+		t.Errorf("Expected every stat to be 1s, got %+v", stats)
+	}
+}
+
+func TestMedianChange(t *testing.T) {
+	previous := BenchmarkStats{Median: 100 * time.Millisecond}
+
+	if change := (BenchmarkStats{Median: 150 * time.Millisecond}).MedianChange(previous); change != 0.5 {
+		t.Errorf("Expected a change of 0.5, got %v", change)
+	}
+	if change := (BenchmarkStats{Median: 50 * time.Millisecond}).MedianChange(previous); change != -0.5 {
+		t.Errorf("Expected a change of -0.5, got %v", change)
+	}
+	if change := (BenchmarkStats{Median: 50 * time.Millisecond}).MedianChange(BenchmarkStats{}); change != 0 {
+		t.Errorf("Expected no change without a previous median, got %v", change)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	var tests = []struct {
+		bytes    uint64
+		expected string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{5 * 1024 * 1024, "5.0 MiB"},
+	}
+
+	for _, test := range tests {
+		if got := FormatBytes(test.bytes); got != test.expected {
+			t.Errorf("Expected %v bytes to be %q, got %q", test.bytes, test.expected, got)
+		}
+	}
+}
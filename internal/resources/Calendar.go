@@ -1,12 +1,264 @@
 package resources
 
-type Year struct {
-	numStars    int
-	days        []*Day
-	leaderboard Leaderboard
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.dalton.dog/aocgo/internal/api"
+	"go.dalton.dog/aocgo/internal/cache"
+	"go.dalton.dog/aocgo/internal/styles"
+	"go.dalton.dog/aocgo/internal/utils"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// Answer stored for the final day's second part, which is a free star for finishing every other puzzle
+const FINAL_STAR_ANSWER = "Merry Christmas!"
+
+// EventCalendar describes the schedule of a year's event, and how its stars are earned
+type EventCalendar struct {
+	Year    int
+	NumDays int
+}
+
+// GetEventCalendar returns the schedule for a year's event. Events before 2025 have 25 days, and later ones have 12.
+func GetEventCalendar(year int) EventCalendar {
+	return EventCalendar{Year: year, NumDays: utils.GetNumDays(year)}
+}
+
+// HasDay returns true if the day is part of the event
+func (c EventCalendar) HasDay(day int) bool {
+	return day >= 1 && day <= c.NumDays
+}
+
+// IsFinalDay returns true if the day is the last day of the event.
+// The final day only has one puzzle, and its second star is given once every other star has been earned.
+func (c EventCalendar) IsFinalDay(day int) bool {
+	return day == c.NumDays
+}
+
+// TotalStars returns the number of stars that can be earned in the event, counting the final day's free star
+func (c EventCalendar) TotalStars() int {
+	return c.NumDays * 2
+}
+
+// UnlockedDays returns the number of days that have unlocked so far
+func (c EventCalendar) UnlockedDays() int {
+	maxYear, maxDay := utils.GetCurrentMaxYearAndDay()
+	if c.Year < maxYear {
+		return c.NumDays
+	} else if c.Year == maxYear {
+		return maxDay
+	}
+	return 0
+}
+
+// Number of stars a calendar day has, from its class on the calendar page
+var calendarStarClasses = map[string]int{
+	"calendar-complete":     1,
+	"calendar-verycomplete": 2,
+}
+
+var calendarDayRegex = regexp.MustCompile(`^calendar-day(\d+)$`)
+
+// Matches the colors the page's stylesheet gives its art, like ".calendar .calendar-color-g { color:#00cc00; }"
+var calendarColorRegex = regexp.MustCompile(`\.(calendar-color-[\w-]+)\s*\{(?:[^}]*?[;\s])?color:\s*(#[0-9a-fA-F]{3,6})`)
+var inlineColorRegex = regexp.MustCompile(`(?:^|;)\s*color:\s*(#[0-9a-fA-F]{3,6})`)
+
+// CalendarDay is a single day on a year's calendar
+type CalendarDay struct {
+	Day      int
+	Stars    int
+	Unlocked bool
+
+	// Line of the art that belongs to the day
+	Line int
+}
+
+// CalendarSegment is a run of the calendar's art in a single color.
+// Day is set if the segment belongs to a day's part of the art.
+type CalendarSegment struct {
+	Text  string
+	Color string `json:",omitempty"`
+	Day   int    `json:",omitempty"`
+}
+
+// Calendar is a year's calendar page, with the user's stars for each day and the art they've unlocked so far
+type Calendar struct {
+	Year int
+
+	// Days that are on the calendar, in order
+	Days []*CalendarDay
+
+	// Lines of the calendar's art
+	Art [][]CalendarSegment
+}
+
+func (c *Calendar) GetID() string                { return strconv.Itoa(c.Year) }
+func (c *Calendar) GetBucketName() string        { return cache.CALENDAR }
+func (c *Calendar) MarshalData() ([]byte, error) { return json.Marshal(c) }
+func (c *Calendar) SaveResource()                { cache.SaveResource(c) }
+
+// LoadOrCreateCalendar will load a year's calendar from the cache, or from the site if it isn't cached
+func LoadOrCreateCalendar(year int) (*Calendar, error) {
+	calData, err := loadFromCache(cache.CALENDAR, strconv.Itoa(year))
+	if err != nil {
+		return nil, err
+	}
+
+	if calData != nil {
+		var calendar *Calendar
+		json.Unmarshal(calData, &calendar)
+		return calendar, nil
+	}
+
+	calendar := &Calendar{Year: year}
+	if err := calendar.LoadCalendar(); err != nil {
+		return nil, err
+	}
+
+	calendar.SaveResource()
+
+	return calendar, nil
+}
+
+// LoadCalendar will load the calendar's days and art from the site
+func (c *Calendar) LoadCalendar() error {
+	resp, err := api.NewGetReq(api.YearURL(c.Year), "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return c.parseCalendar(doc)
+}
+
+// GetDay returns a day on the calendar, or nil if it isn't on the calendar
+func (c *Calendar) GetDay(day int) *CalendarDay {
+	for _, d := range c.Days {
+		if d.Day == day {
+			return d
+		}
+	}
+	return nil
+}
+
+// NumStars returns the number of stars earned across every day on the calendar
+func (c *Calendar) NumStars() int {
+	stars := 0
+	for _, d := range c.Days {
+		stars += d.Stars
+	}
+	return stars
+}
+
+// Parses the calendar's art out of the page, keeping track of the day and color of each piece of it
+func (c *Calendar) parseCalendar(doc *goquery.Document) error {
+	pre := doc.Find("pre.calendar").First()
+	if pre.Length() == 0 {
+		return errors.New("Unable to find the calendar on the page")
+	}
+
+	colors := map[string]string{}
+	for _, match := range calendarColorRegex.FindAllStringSubmatch(doc.Find("style").Text(), -1) {
+		colors[match[1]] = match[2]
+	}
+
+	days := map[int]*CalendarDay{}
+	c.Days = nil
+	c.Art = [][]CalendarSegment{nil}
+
+	var walk func(node *html.Node, color string, day int)
+	walk = func(node *html.Node, color string, day int) {
+		if node.Type == html.TextNode {
+			for i, text := range strings.Split(node.Data, "\n") {
+				if i > 0 {
+					c.Art = append(c.Art, nil)
+				}
+				if text == "" {
+					continue
+				}
+
+				line := len(c.Art) - 1
+				c.Art[line] = append(c.Art[line], CalendarSegment{Text: text, Color: color, Day: day})
+				if day > 0 {
+					days[day].Line = line
+				}
+			}
+			return
+		}
+
+		if node.Type == html.ElementNode {
+			if node.Data == "script" || node.Data == "style" {
+				return
+			}
+
+			el := goquery.NewDocumentFromNode(node).Selection
+			classes := strings.Fields(el.AttrOr("class", ""))
+
+			// Days are found first, since the star classes are on the same element
+			for _, class := range classes {
+				if match := calendarDayRegex.FindStringSubmatch(class); match != nil {
+					day, _ = strconv.Atoi(match[1])
+					if days[day] == nil {
+						days[day] = &CalendarDay{Day: day}
+						c.Days = append(c.Days, days[day])
+					}
+					days[day].Unlocked = days[day].Unlocked || node.Data == "a"
+				}
+			}
+
+			for _, class := range classes {
+				if stars, ok := calendarStarClasses[class]; ok && day > 0 {
+					days[day].Stars = stars
+				} else if hex, ok := colors[class]; ok {
+					color = hex
+				} else if class == "calendar-mark-complete" || class == "calendar-mark-verycomplete" {
+					color = getCalendarMarkColor(class, days[day])
+				}
+			}
+
+			if match := inlineColorRegex.FindStringSubmatch(el.AttrOr("style", "")); match != nil {
+				color = match[1]
+			}
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child, color, day)
+		}
+	}
+
+	for _, node := range pre.Nodes {
+		walk(node, "", 0)
+	}
+
+	// The page's formatting leaves blank lines around the art
+	for len(c.Art) > 0 && len(c.Art[len(c.Art)-1]) == 0 {
+		c.Art = c.Art[:len(c.Art)-1]
+	}
+
+	slices.SortFunc(c.Days, func(a, b *CalendarDay) int { return a.Day - b.Day })
+	return nil
 }
 
-type Day struct {
-	puzzle      Puzzle
-	leaderboard Leaderboard
+// Returns the color of a day's star mark, which is only lit up once the star is earned
+func getCalendarMarkColor(class string, day *CalendarDay) string {
+	if day == nil {
+		return string(styles.NoStarsColor)
+	} else if class == "calendar-mark-complete" && day.Stars == 1 {
+		return string(styles.FirstStarColor)
+	} else if day.Stars == 2 {
+		return string(styles.BothStarsColor)
+	}
+	return string(styles.NoStarsColor)
 }
@@ -0,0 +1,307 @@
+package resources
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"go.dalton.dog/aocgo/internal/api"
+	"go.dalton.dog/aocgo/internal/styles"
+	"go.dalton.dog/aocgo/internal/utils"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Message sent once the calendar has been loaded from the site again
+type calendarRefreshMsg struct {
+	calendar *Calendar
+	err      error
+}
+
+type CalendarModel struct {
+	calendar    *Calendar
+	userSession string
+
+	// Day the cursor is on, and the day to open once the calendar closes
+	selected int
+	open     int
+
+	height     int
+	help       help.Model
+	keys       calendarKeymap
+	status     string
+	refreshing bool
+}
+
+// NewCalendarViewport shows a year's calendar, where the arrow keys move between the unlocked days.
+// Pressing enter on a day opens its puzzle, and the calendar comes back once the puzzle is closed.
+func NewCalendarViewport(calendar *Calendar, userSession string) {
+	selected := 0
+	for _, day := range calendar.Days {
+		if day.Unlocked {
+			selected = day.Day
+		}
+	}
+
+	for {
+		m := CalendarModel{
+			calendar:    calendar,
+			userSession: userSession,
+			selected:    selected,
+			help:        help.New(),
+			keys:        calendarKeys,
+		}
+
+		p := tea.NewProgram(m, tea.WithAltScreen())
+		finalModel, err := p.Run()
+		if err != nil {
+			fmt.Println("Couldn't run viewport:", err)
+			os.Exit(1)
+		}
+
+		m = finalModel.(CalendarModel)
+		if m.open == 0 {
+			return
+		}
+		calendar, selected = m.calendar, m.selected
+
+		puzzle, err := LoadOrCreatePuzzle(calendar.Year, m.open, userSession)
+		if err != nil {
+			fmt.Println("Unable to load puzzle:", err)
+			os.Exit(1)
+		}
+		NewPuzzleViewport(puzzle)
+	}
+}
+
+func (m CalendarModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m CalendarModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+
+		case key.Matches(msg, m.keys.Open):
+			if m.selected != 0 {
+				m.open = m.selected
+				return m, tea.Quit
+			}
+
+		case key.Matches(msg, m.keys.Up):
+			m.moveByLine(-1)
+		case key.Matches(msg, m.keys.Down):
+			m.moveByLine(1)
+		case key.Matches(msg, m.keys.Left):
+			m.moveByDay(-1)
+		case key.Matches(msg, m.keys.Right):
+			m.moveByDay(1)
+
+		case key.Matches(msg, m.keys.Browser):
+			utils.LaunchURL(m.calendar.dayURL(m.selected))
+			m.status = "Page launched in browser!"
+
+		case key.Matches(msg, m.keys.Refresh):
+			if !m.refreshing {
+				m.refreshing = true
+				m.status = "Refreshing..."
+				return m, refreshCalendar(m.calendar.Year)
+			}
+		}
+
+	case calendarRefreshMsg:
+		m.refreshing = false
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Unable to refresh: %v", msg.err)
+		} else {
+			m.calendar = msg.calendar
+			m.status = "Calendar refreshed!"
+		}
+
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	}
+
+	return m, nil
+}
+
+// Loads the year's calendar from the site again. The calendar being shown isn't touched until it's done.
+func refreshCalendar(year int) tea.Cmd {
+	return func() tea.Msg {
+		calendar := &Calendar{Year: year}
+		if err := calendar.LoadCalendar(); err != nil {
+			return calendarRefreshMsg{err: err}
+		}
+
+		calendar.SaveResource()
+		return calendarRefreshMsg{calendar: calendar}
+	}
+}
+
+// Moves to the closest unlocked day above or below the selected one in the art
+func (m *CalendarModel) moveByLine(direction int) {
+	days := m.calendar.unlockedDays()
+	slices.SortStableFunc(days, func(a, b *CalendarDay) int { return a.Line - b.Line })
+	m.moveWithin(days, direction)
+}
+
+// Moves to the previous or next unlocked day
+func (m *CalendarModel) moveByDay(direction int) {
+	m.moveWithin(m.calendar.unlockedDays(), direction)
+}
+
+func (m *CalendarModel) moveWithin(days []*CalendarDay, direction int) {
+	i := slices.IndexFunc(days, func(d *CalendarDay) bool { return d.Day == m.selected })
+	if i == -1 {
+		return
+	}
+
+	if next := i + direction; next >= 0 && next < len(days) {
+		m.selected = days[next].Day
+		m.status = ""
+	}
+}
+
+func (m CalendarModel) View() string {
+	header := m.headerView()
+	footer := m.footerView()
+
+	// Only the lines around the selected day are shown if the art doesn't fit
+	lines := m.calendar.Art
+	if height := m.height - lipgloss.Height(header) - lipgloss.Height(footer); height > 0 && len(lines) > height {
+		start := 0
+		if day := m.calendar.GetDay(m.selected); day != nil {
+			start = min(max(0, day.Line-height/2), len(lines)-height)
+		}
+		lines = lines[start : start+height]
+	}
+
+	var art strings.Builder
+	for _, line := range lines {
+		cursor := "  "
+		if slices.ContainsFunc(line, func(s CalendarSegment) bool { return s.Day == m.selected && m.selected != 0 }) {
+			cursor = lipgloss.NewStyle().Foreground(styles.StarColor).Render("> ")
+		}
+
+		art.WriteString(cursor)
+		for _, segment := range line {
+			style := lipgloss.NewStyle().Foreground(styles.NormalTextColor)
+			if segment.Color != "" {
+				style = style.Foreground(lipgloss.Color(segment.Color))
+			}
+			if segment.Day == m.selected && m.selected != 0 {
+				style = style.Bold(true)
+			}
+			art.WriteString(style.Render(segment.Text))
+		}
+		art.WriteString("\n")
+	}
+
+	return fmt.Sprintf("%s\n%s%s", header, art.String(), footer)
+}
+
+func (m CalendarModel) headerView() string {
+	title := titleStyle.Render(fmt.Sprintf("Advent of Code %d -- %d/%d stars", m.calendar.Year, m.calendar.NumStars(), GetEventCalendar(m.calendar.Year).TotalStars()))
+	line := strings.Repeat("─", max(0, ViewportWidth-lipgloss.Width(title)))
+	return lipgloss.JoinHorizontal(lipgloss.Center, title, line)
+}
+
+func (m CalendarModel) footerView() string {
+	info := "No puzzles have unlocked yet"
+	if day := m.calendar.GetDay(m.selected); day != nil {
+		info = fmt.Sprintf("Day %d -- %s", day.Day, []string{"No stars", "One star", "Two stars"}[min(day.Stars, 2)])
+	}
+
+	info = infoStyle.Render(info)
+	line := strings.Repeat("─", max(0, ViewportWidth-lipgloss.Width(info)))
+	sOut := lipgloss.JoinHorizontal(lipgloss.Center, line, info)
+	sOut += "\n" + m.help.View(m.keys)
+	if m.status != "" {
+		sOut += " -- " + m.status
+	}
+
+	return sOut
+}
+
+// Returns the days that have unlocked, in order
+func (c *Calendar) unlockedDays() []*CalendarDay {
+	var days []*CalendarDay
+	for _, day := range c.Days {
+		if day.Unlocked {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// Returns the URL of a day's puzzle, or the calendar itself if no day is selected
+func (c *Calendar) dayURL(day int) string {
+	if day == 0 {
+		return api.YearURL(c.Year)
+	}
+	return api.DayURL(c.Year, day)
+}
+
+type calendarKeymap struct {
+	Up      key.Binding
+	Down    key.Binding
+	Left    key.Binding
+	Right   key.Binding
+	Open    key.Binding
+	Browser key.Binding
+	Refresh key.Binding
+	Quit    key.Binding
+}
+
+func (k calendarKeymap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Left, k.Right, k.Open, k.Browser, k.Refresh, k.Quit}
+}
+
+func (k calendarKeymap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Left, k.Right},
+		{k.Open, k.Browser, k.Refresh, k.Quit},
+	}
+}
+
+var calendarKeys = calendarKeymap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "move up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "move down"),
+	),
+	Left: key.NewBinding(
+		key.WithKeys("left", "h"),
+		key.WithHelp("←/h", "previous day"),
+	),
+	Right: key.NewBinding(
+		key.WithKeys("right", "l"),
+		key.WithHelp("→/l", "next day"),
+	),
+	Open: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "open puzzle"),
+	),
+	Browser: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "[B]rowser"),
+	),
+	Refresh: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "[R]efresh"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "esc", "ctrl+c"),
+		key.WithHelp("q/esc", "[Q]uit"),
+	),
+}
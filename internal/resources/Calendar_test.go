@@ -0,0 +1,136 @@
+package resources
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.dalton.dog/aocgo/aocfake"
+	"go.dalton.dog/aocgo/internal/styles"
+	"go.dalton.dog/aocgo/internal/utils"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestGetEventCalendar(t *testing.T) {
+	var tests = []struct {
+		year, days, stars, finalDay int
+	}{
+		{2015, 25, 50, 25},
+		{2024, 25, 50, 25},
+		{2025, 12, 24, 12},
+		{2026, 12, 24, 12},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.year), func(t *testing.T) {
+			calendar := GetEventCalendar(test.year)
+			if calendar.NumDays != test.days {
+				t.Errorf("Days mismatch. Got %d, expected %d", calendar.NumDays, test.days)
+			}
+
+			if calendar.TotalStars() != test.stars {
+				t.Errorf("Stars mismatch. Got %d, expected %d", calendar.TotalStars(), test.stars)
+			}
+
+			if !calendar.IsFinalDay(test.finalDay) || calendar.IsFinalDay(test.finalDay-1) {
+				t.Errorf("Expected day %d to be the only final day", test.finalDay)
+			}
+
+			if !calendar.HasDay(test.finalDay) || calendar.HasDay(test.finalDay+1) || calendar.HasDay(0) {
+				t.Errorf("Expected the event to run from day 1 to %d", test.finalDay)
+			}
+		})
+	}
+}
+
+func TestLoadCalendar(t *testing.T) {
+	fake := startFakeServer(t)
+	fake.AddPuzzle(&aocfake.Puzzle{Year: 2015, Day: 3, Title: "Solved", Solved: 2})
+
+	calendar, err := LoadOrCreateCalendar(2015)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if numDays := GetEventCalendar(2015).NumDays; len(calendar.Days) != numDays {
+		t.Fatalf("Expected %d days, got %d", numDays, len(calendar.Days))
+	}
+
+	for _, day := range []int{1, 2, 3} {
+		if !calendar.GetDay(day).Unlocked {
+			t.Errorf("Expected day %d to be unlocked", day)
+		}
+	}
+	if calendar.GetDay(4).Unlocked {
+		t.Errorf("Expected day 4 to be locked")
+	}
+
+	if calendar.GetDay(3).Stars != 2 || calendar.NumStars() != 2 {
+		t.Errorf("Expected only day 3's two stars, got %d of %d", calendar.GetDay(3).Stars, calendar.NumStars())
+	}
+
+	// The newest day is at the top, so day 1 is on the last line
+	if calendar.GetDay(1).Line != len(calendar.Art)-1 || calendar.GetDay(25).Line != 0 {
+		t.Errorf("Unexpected lines for days 1 and 25: %d and %d", calendar.GetDay(1).Line, calendar.GetDay(25).Line)
+	}
+
+	cached, err := LoadOrCreateCalendar(2015)
+	if err != nil || cached.NumStars() != 2 || len(cached.Art) != len(calendar.Art) {
+		t.Errorf("Expected the cached calendar to match, got %+v (err %v)", cached, err)
+	}
+
+	short, err := LoadOrCreateCalendar(utils.SHORT_EVENT_YEAR)
+	if err != nil || len(short.Days) != utils.SHORT_EVENT_DAYS {
+		t.Errorf("Expected %d days in %d's calendar, got %+v (err %v)", utils.SHORT_EVENT_DAYS, utils.SHORT_EVENT_YEAR, short, err)
+	}
+}
+
+func TestParseCalendar(t *testing.T) {
+	page := `<html><head><style>
+.calendar .calendar-color-g { color:#00cc00; }
+.calendar .calendar-color-w { background-color:#333333; color:#ffffff; }
+</style></head><body><main>
+<pre class="calendar">
+<span aria-hidden="true" class="calendar-day3">         </span>
+<a aria-label="Day 2, one star" href="/2016/day/2" class="calendar-day2 calendar-complete"><span class="calendar-color-w">.</span><span style="color:#ff0000">o</span>  <span class="calendar-day"> 2</span> <span class="calendar-mark-complete">*</span><span class="calendar-mark-verycomplete">*</span></a>
+<a aria-label="Day 1, two stars" href="/2016/day/1" class="calendar-verycomplete calendar-day1"><span class="calendar-color-g">/\</span>  <span class="calendar-day"> 1</span> <span class="calendar-mark-complete">*</span><span class="calendar-mark-verycomplete">*</span></a>
+</pre></main></body></html>`
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calendar := &Calendar{Year: 2016}
+	if err := calendar.parseCalendar(doc); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(calendar.Days) != 3 || calendar.Days[0].Day != 1 {
+		t.Fatalf("Expected days 1 through 3 in order, got %+v", calendar.Days)
+	}
+
+	if calendar.GetDay(1).Stars != 2 || calendar.GetDay(2).Stars != 1 || calendar.GetDay(3).Unlocked {
+		t.Errorf("Unexpected star state %+v %+v %+v", calendar.GetDay(1), calendar.GetDay(2), calendar.GetDay(3))
+	}
+
+	dayTwo := calendar.Art[calendar.GetDay(2).Line]
+	var colors []string
+	for _, segment := range dayTwo {
+		if segment.Day != 2 {
+			t.Errorf("Expected segment %q to belong to day 2", segment.Text)
+		}
+		colors = append(colors, segment.Color)
+	}
+
+	expected := []string{"#ffffff", "#ff0000", "", "", "", string(styles.FirstStarColor), string(styles.NoStarsColor)}
+	if !slices.Equal(colors, expected) {
+		t.Errorf("Unexpected colors for day 2.\nGot      %q\nExpected %q", colors, expected)
+	}
+
+	if calendar.Art[calendar.GetDay(1).Line][0].Color != "#00cc00" {
+		t.Errorf("Expected day 1's art to use the stylesheet's color")
+	}
+}
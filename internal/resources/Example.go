@@ -0,0 +1,60 @@
+package resources
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Example is an example input from a puzzle's page, along with the answer the page says it should produce
+type Example struct {
+	Input string
+
+	// Empty if an answer couldn't be found for the example
+	Expected string
+}
+
+// GetExamples returns the examples found on the puzzle's page for a given part
+func (p *Puzzle) GetExamples(part int) []Example {
+	return p.Examples[part]
+}
+
+// Finds the examples in an article, along with the last emphasized answer in the article.
+// Each <pre><code> block is a candidate input, and its answer is the last emphasized
+// code that comes after it, before the next candidate.
+func parseExamples(article *goquery.Selection) ([]Example, string) {
+	var examples []Example
+	var lastAnswer string
+
+	article.Children().Each(func(i int, sel *goquery.Selection) {
+		if goquery.NodeName(sel) == "pre" {
+			input := strings.TrimRight(sel.Find("code").Text(), "\n")
+			if input != "" {
+				examples = append(examples, Example{Input: input})
+			}
+			return
+		}
+
+		sel.Find("code > em, em > code").Each(func(j int, s *goquery.Selection) {
+			lastAnswer = strings.TrimSpace(s.Text())
+			if len(examples) > 0 && lastAnswer != "" {
+				examples[len(examples)-1].Expected = lastAnswer
+			}
+		})
+	})
+
+	return examples, lastAnswer
+}
+
+// LoadExamples reloads the puzzle's page if it was stored before examples were being saved
+func (p *Puzzle) LoadExamples() error {
+	if p.Examples != nil {
+		return nil
+	}
+
+	if err := p.loadPageData(); err != nil {
+		return err
+	}
+	p.SaveResource()
+	return nil
+}
@@ -0,0 +1,50 @@
+package resources
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestParseExamples(t *testing.T) {
+	html := `<article><h2>--- Day 3: Test ---</h2>
+<p>For example:</p>
+<pre><code>1,2
+3,4
+</code></pre>
+<p>This gives <code>3</code> and then <code><em>10</em></code>.</p>
+<p>A bigger example:</p>
+<pre><code>5,6</code></pre>
+<ul><li>This one gives <em><code>11</code></em>.</li></ul>
+<pre><code>7,8</code></pre>
+<p>What do you get?</p>
+</article>`
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	examples, lastAnswer := parseExamples(doc.Find("article"))
+
+	expected := []Example{
+		{Input: "1,2\n3,4", Expected: "10"},
+		{Input: "5,6", Expected: "11"},
+		{Input: "7,8", Expected: ""},
+	}
+
+	if len(examples) != len(expected) {
+		t.Fatalf("Expected %v examples, got %+v", len(expected), examples)
+	}
+
+	for i := range expected {
+		if examples[i] != expected[i] {
+			t.Errorf("Expected example %v to be %+v, got %+v", i, expected[i], examples[i])
+		}
+	}
+
+	if lastAnswer != "11" {
+		t.Errorf("Expected the last answer to be 11, got %q", lastAnswer)
+	}
+}
@@ -0,0 +1,145 @@
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.dalton.dog/aocgo/internal/styles"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+)
+
+// SortedSubmissions returns every submission for the puzzle, oldest first
+func (p *Puzzle) SortedSubmissions() []*Submission {
+	var subs []*Submission
+	for _, part := range []int{1, 2} {
+		for _, sub := range p.Submissions[part] {
+			// Submissions stored before the part was recorded only know which list they're in
+			if sub.Part == 0 {
+				sub.Part = part
+			}
+			subs = append(subs, sub)
+		}
+	}
+
+	sort.SliceStable(subs, func(i, j int) bool {
+		return subs[i].When.Before(subs[j].When)
+	})
+
+	return subs
+}
+
+// GetHistoryTitle will get the appropriate viewport title for the puzzle's submission history
+func (p *Puzzle) GetHistoryTitle() string {
+	return fmt.Sprintf("Submission History -- Year: %d, Day: %d", p.Year, p.Day)
+}
+
+// GetHistoryContent will get a table of every answer submitted for the puzzle
+func (p *Puzzle) GetHistoryContent() string {
+	subs := p.SortedSubmissions()
+	if len(subs) == 0 {
+		return "No answers have been submitted for this puzzle."
+	}
+
+	t := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
+		Headers("Part", "Submitted", "Answer", "Verdict", "Hint", "Lockout").
+		StyleFunc(styles.GetHistoryStyle)
+
+	for _, sub := range subs {
+		verdict := styles.IncorrectAnswerStyle.Render(sub.Outcome.String())
+		if sub.Correct {
+			verdict = styles.CorrectAnswerStyle.Render(OutcomeCorrect.String())
+		} else if sub.Outcome == OutcomeUnknown {
+			// Submissions stored before outcomes were recorded only know they weren't correct
+			verdict = styles.IncorrectAnswerStyle.Render(OutcomeWrong.String())
+		}
+
+		hint := "-"
+		if sub.Hint != NoHint {
+			hint = string(sub.Hint)
+		}
+
+		lockout := "-"
+		if sub.Lockout > 0 {
+			lockout = sub.Lockout.String()
+		}
+
+		t.Row(strconv.Itoa(sub.Part), formatSubmitTime(sub.When), sub.Answer, verdict, hint, lockout)
+	}
+
+	return t.Render()
+}
+
+// GetYearHistoryTitle will get the appropriate viewport title for a year's submission history
+func GetYearHistoryTitle(year int) string {
+	return fmt.Sprintf("Submission History -- Year: %d", year)
+}
+
+// GetYearHistoryContent will get a summary table of every stored puzzle's submissions for a year.
+// Puzzles that haven't been stored are skipped rather than loaded from the site.
+func GetYearHistoryContent(year int) string {
+	lastDay := GetEventCalendar(year).UnlockedDays()
+
+	t := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
+		Headers("Day", "Stars", "Part 1", "Part 2", "Wrong", "Last Submitted").
+		StyleFunc(styles.GetYearHistoryStyle)
+
+	var totalStars, totalOne, totalTwo, totalWrong int
+	for day := 1; day <= lastDay; day++ {
+		p := LoadCachedPuzzle(year, day)
+		if p == nil {
+			t.Row(strconv.Itoa(day), "-", "-", "-", "-", "-")
+			continue
+		}
+
+		stars := 0
+		if p.AnswerOne != "" {
+			stars++
+		}
+		if p.AnswerTwo != "" {
+			stars++
+		}
+
+		wrong := 0
+		var last time.Time
+		for _, sub := range p.SortedSubmissions() {
+			if !sub.Correct {
+				wrong++
+			}
+			last = sub.When
+		}
+
+		lastSubmitted := "-"
+		if len(p.Submissions[1])+len(p.Submissions[2]) > 0 {
+			lastSubmitted = formatSubmitTime(last)
+		}
+
+		t.Row(strconv.Itoa(day), strings.Repeat("*", stars), strconv.Itoa(len(p.Submissions[1])),
+			strconv.Itoa(len(p.Submissions[2])), strconv.Itoa(wrong), lastSubmitted)
+
+		totalStars += stars
+		totalOne += len(p.Submissions[1])
+		totalTwo += len(p.Submissions[2])
+		totalWrong += wrong
+	}
+
+	t.Row("Total", strconv.Itoa(totalStars), strconv.Itoa(totalOne), strconv.Itoa(totalTwo), strconv.Itoa(totalWrong), "")
+
+	return t.Render()
+}
+
+// Submissions stored by older versions don't have a timestamp
+func formatSubmitTime(when time.Time) string {
+	if when.IsZero() {
+		return "Unknown"
+	}
+	return when.Local().Format(time.Stamp)
+}
@@ -48,19 +48,22 @@ type Leaderboard struct {
 }
 
 func (lb *Leaderboard) GetID() string                { return utils.GetResouceBucketID(lb.Year, lb.Day) }
-func (lb *Leaderboard) GetBucketName() string        { return cache.PUZZLES }
+func (lb *Leaderboard) GetBucketName() string        { return cache.LEADERBOARDS }
 func (lb *Leaderboard) MarshalData() ([]byte, error) { return json.Marshal(lb) }
 func (lb *Leaderboard) SaveResource()                { cache.SaveResource(lb) }
 
 // LoadOrCreateLeaderboard will create a leaderboard object based on the parameters.
 // If you want to create a leaderboard for an entire year, pass in 0 for day
-func LoadOrCreateLeaderboard(year, day int) *Leaderboard {
-	lbData := cache.LoadResource(cache.LEADERBOARDS, utils.GetResouceBucketID(year, day))
+func LoadOrCreateLeaderboard(year, day int) (*Leaderboard, error) {
+	lbData, err := loadFromCache(cache.LEADERBOARDS, utils.GetResouceBucketID(year, day))
+	if err != nil {
+		return nil, err
+	}
 
 	if lbData != nil {
 		var lb *Leaderboard
 		json.Unmarshal(lbData, &lb)
-		return lb
+		return lb, nil
 	}
 
 	lb := &Leaderboard{
@@ -72,21 +75,21 @@ func LoadOrCreateLeaderboard(year, day int) *Leaderboard {
 		lb.SecondHundred = make([]*Placing, 0, 100)
 	}
 
-	lb.LoadPlacings()
+	if err := lb.LoadPlacings(); err != nil {
+		return nil, err
+	}
 
 	lb.SaveResource()
 
-	return lb
+	return lb, nil
 }
 
 // LoadPlacings will load all of the placings for a given year or date
-func (lb *Leaderboard) LoadPlacings() {
+func (lb *Leaderboard) LoadPlacings() error {
 	if lb.Day == 0 {
-		lb.loadYearlyLB()
-	} else {
-		lb.loadDailyLB()
+		return lb.loadYearlyLB()
 	}
-
+	return lb.loadDailyLB()
 }
 
 // GetTitle will get the appropriate viewport title for the leaderboard
@@ -107,7 +110,7 @@ func (lb *Leaderboard) GetContent() string {
 }
 
 func (lb *Leaderboard) loadYearlyLB() error {
-	URL := fmt.Sprintf("https://adventofcode.com/%v/leaderboard", lb.Year)
+	URL := api.YearURL(lb.Year) + "/leaderboard"
 	resp, err := api.NewGetReq(URL, "")
 
 	if err != nil {
@@ -181,7 +184,7 @@ func (lb *Leaderboard) getYearlyContent() string {
 
 // LoadPositions will get all of the daily positions
 func (lb *Leaderboard) loadDailyLB() error {
-	URL := fmt.Sprintf("https://adventofcode.com/%v/leaderboard/day/%v", lb.Year, lb.Day)
+	URL := fmt.Sprintf("%v/leaderboard/day/%v", api.YearURL(lb.Year), lb.Day)
 	resp, err := api.NewGetReq(URL, "")
 
 	if err != nil {
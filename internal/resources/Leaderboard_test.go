@@ -0,0 +1,58 @@
+package resources
+
+import (
+	"testing"
+)
+
+func TestLoadYearlyLeaderboard(t *testing.T) {
+	startFakeServer(t)
+
+	lb, err := LoadOrCreateLeaderboard(2015, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(lb.FirstHundred) != 100 {
+		t.Fatalf("Expected 100 placings, got %d", len(lb.FirstHundred))
+	}
+
+	first := lb.FirstHundred[0]
+	if first.Position != 1 || first.Score != 4990 || first.DisplayName != "Elf 1" || first.UserID != "1" {
+		t.Errorf("Unexpected first place %+v", first)
+	}
+}
+
+func TestLoadDailyLeaderboard(t *testing.T) {
+	startFakeServer(t)
+
+	lb, err := LoadOrCreateLeaderboard(2015, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(lb.FirstHundred) != 100 || len(lb.SecondHundred) != 100 {
+		t.Fatalf("Expected 100 placings for each star, got %d and %d", len(lb.FirstHundred), len(lb.SecondHundred))
+	}
+
+	first := lb.FirstHundred[0]
+	if first.DisplayName != "Elf 101" || first.FinishTime != "Dec 01 00:11:47" {
+		t.Errorf("Unexpected first place %+v", first)
+	}
+}
+
+func TestLoadPrivateLeaderboard(t *testing.T) {
+	startFakeServer(t)
+
+	lb, err := LoadOrCreatePrivateLeaderboard(2015, 0, "12345")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(lb.Members) != 2 {
+		t.Errorf("Expected 2 members, got %d", len(lb.Members))
+	}
+
+	if _, err := LoadOrCreatePrivateLeaderboard(2015, 0, "999"); err == nil {
+		t.Errorf("Expected an error loading a leaderboard the user isn't a member of")
+	}
+}
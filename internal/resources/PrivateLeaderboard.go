@@ -0,0 +1,277 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.dalton.dog/aocgo/internal/api"
+	"go.dalton.dog/aocgo/internal/cache"
+	"go.dalton.dog/aocgo/internal/styles"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+)
+
+// Path for a private leaderboard's JSON API
+const PRIVATE_LB_PATH = "/%v/leaderboard/private/view/%v.json"
+
+// StarInfo represents a single star obtained by a private leaderboard member
+type StarInfo struct {
+	GetStarTS int64 `json:"get_star_ts"`
+	StarIndex int64 `json:"star_index"`
+}
+
+// PrivateMember represents a single member of a private leaderboard
+type PrivateMember struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Stars       int    `json:"stars"`
+	LocalScore  int    `json:"local_score"`
+	GlobalScore int    `json:"global_score"`
+	LastStarTS  int64  `json:"last_star_ts"`
+
+	// CompletionDayLevel maps a day to the parts completed on that day
+	CompletionDayLevel map[int]map[int]*StarInfo `json:"completion_day_level"`
+}
+
+// GetDisplayName returns the member's name, or the same placeholder the site uses for anonymous users
+func (m *PrivateMember) GetDisplayName() string {
+	if m.Name == "" {
+		return fmt.Sprintf("(anonymous user #%d)", m.ID)
+	}
+	return m.Name
+}
+
+// GetStarTime returns when the member obtained the star for a given day and part
+func (m *PrivateMember) GetStarTime(day, part int) (time.Time, bool) {
+	star, ok := m.CompletionDayLevel[day][part]
+	if !ok || star == nil {
+		return time.Time{}, false
+	}
+	return time.Unix(star.GetStarTS, 0), true
+}
+
+// PrivateLeaderboard is a private leaderboard for a given year, loaded from the JSON API
+type PrivateLeaderboard struct {
+	Year    int
+	ID      string
+	Event   string                    `json:"event"`
+	OwnerID int                       `json:"owner_id"`
+	Members map[string]*PrivateMember `json:"members"`
+
+	// Day is only used to pick which view to display, the whole year is always stored
+	Day int `json:"-"`
+}
+
+func (lb *PrivateLeaderboard) GetID() string                { return getPrivateBucketID(lb.Year, lb.ID) }
+func (lb *PrivateLeaderboard) GetBucketName() string        { return cache.LEADERBOARDS }
+func (lb *PrivateLeaderboard) MarshalData() ([]byte, error) { return json.Marshal(lb) }
+func (lb *PrivateLeaderboard) SaveResource()                { cache.SaveResource(lb) }
+func (lb *PrivateLeaderboard) GetResourceType() string      { return cache.PRIVATE_LEADERBOARD }
+
+// LoadOrCreatePrivateLeaderboard will load a private leaderboard from storage,
+// or from the website if it hasn't been stored yet.
+// Pass in 0 for day to display the yearly standings.
+func LoadOrCreatePrivateLeaderboard(year, day int, id string) (*PrivateLeaderboard, error) {
+	lbData, err := loadFromCache(cache.LEADERBOARDS, getPrivateBucketID(year, id))
+	if err != nil {
+		return nil, err
+	}
+
+	if lbData != nil {
+		var lb *PrivateLeaderboard
+		if err := json.Unmarshal(lbData, &lb); err == nil {
+			lb.Day = day
+			return lb, nil
+		}
+	}
+
+	lb := &PrivateLeaderboard{
+		Year: year,
+		Day:  day,
+		ID:   id,
+	}
+
+	if err := lb.LoadMembers(); err != nil {
+		return nil, err
+	}
+
+	lb.SaveResource()
+
+	return lb, nil
+}
+
+// LoadMembers will load all of the members from the leaderboard's JSON API
+func (lb *PrivateLeaderboard) LoadMembers() error {
+	URL := api.URL(fmt.Sprintf(PRIVATE_LB_PATH, lb.Year, lb.ID))
+	resp, err := api.NewGetReq(URL, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Unable to load private leaderboard %v (status %v)", lb.ID, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return lb.parseMembers(data)
+}
+
+// parseMembers fills in the leaderboard from the JSON API's response body
+func (lb *PrivateLeaderboard) parseMembers(data []byte) error {
+	// Users that aren't logged in or aren't members get redirected to an HTML page
+	if err := json.Unmarshal(data, lb); err != nil {
+		return fmt.Errorf("Unable to parse private leaderboard %v. Make sure you're a member of it: %w", lb.ID, err)
+	}
+
+	if lb.Members == nil {
+		lb.Members = make(map[string]*PrivateMember)
+	}
+
+	return nil
+}
+
+// SortedMembers returns the leaderboard's members in placement order
+func (lb *PrivateLeaderboard) SortedMembers() []*PrivateMember {
+	members := make([]*PrivateMember, 0, len(lb.Members))
+	for _, m := range lb.Members {
+		members = append(members, m)
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		a, b := members[i], members[j]
+		if a.LocalScore != b.LocalScore {
+			return a.LocalScore > b.LocalScore
+		}
+		if a.Stars != b.Stars {
+			return a.Stars > b.Stars
+		}
+		if a.LastStarTS != b.LastStarTS {
+			return a.LastStarTS < b.LastStarTS
+		}
+		return a.ID < b.ID
+	})
+
+	return members
+}
+
+// GetTitle will get the appropriate viewport title for the leaderboard
+func (lb *PrivateLeaderboard) GetTitle() string {
+	if lb.Day == 0 {
+		return fmt.Sprintf("Private Leaderboard %v -- Year: %d", lb.ID, lb.Year)
+	}
+	return fmt.Sprintf("Private Leaderboard %v -- Year: %d, Day: %d", lb.ID, lb.Year, lb.Day)
+}
+
+// GetContent will get the lb content in a printable format
+func (lb *PrivateLeaderboard) GetContent() string {
+	if lb.Day == 0 {
+		return lb.getYearlyContent()
+	}
+	return lb.getDailyContent()
+}
+
+func (lb *PrivateLeaderboard) getYearlyContent() string {
+	t := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
+		Headers("Place", "Score", "Stars", fmt.Sprintf("Days 1-%v", GetEventCalendar(lb.Year).NumDays), "Display Name").
+		StyleFunc(styles.GetPrivateLeaderboardStyle)
+
+	for i, m := range lb.SortedMembers() {
+		t.Row(strconv.Itoa(i+1), strconv.Itoa(m.LocalScore), strconv.Itoa(m.Stars), lb.getStarString(m), m.GetDisplayName())
+	}
+
+	return t.Render()
+}
+
+// Builds the row of stars for a member, one character per day
+func (lb *PrivateLeaderboard) getStarString(m *PrivateMember) string {
+	var sb strings.Builder
+	for day := 1; day <= GetEventCalendar(lb.Year).NumDays; day++ {
+		_, partOne := m.GetStarTime(day, 1)
+		_, partTwo := m.GetStarTime(day, 2)
+
+		if partTwo {
+			sb.WriteString(lipgloss.NewStyle().Foreground(styles.BothStarsColor).Render("*"))
+		} else if partOne {
+			sb.WriteString(lipgloss.NewStyle().Foreground(styles.FirstStarColor).Render("*"))
+		} else {
+			sb.WriteString(lipgloss.NewStyle().Foreground(styles.NoStarsColor).Render("."))
+		}
+	}
+	return sb.String()
+}
+
+func (lb *PrivateLeaderboard) getDailyContent() string {
+	members := make([]*PrivateMember, 0, len(lb.Members))
+	for _, m := range lb.SortedMembers() {
+		if _, ok := m.GetStarTime(lb.Day, 1); ok {
+			members = append(members, m)
+		}
+	}
+
+	// Both stars first, ordered by completion time, then first stars by completion time
+	sort.SliceStable(members, func(i, j int) bool {
+		iTwo, iDone := members[i].GetStarTime(lb.Day, 2)
+		jTwo, jDone := members[j].GetStarTime(lb.Day, 2)
+		if iDone != jDone {
+			return iDone
+		}
+		if iDone {
+			return iTwo.Before(jTwo)
+		}
+
+		iOne, _ := members[i].GetStarTime(lb.Day, 1)
+		jOne, _ := members[j].GetStarTime(lb.Day, 1)
+		return iOne.Before(jOne)
+	})
+
+	t := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99"))).
+		Headers("Place", "Part 1", "Part 2", "Display Name").
+		StyleFunc(styles.GetPrivateDailyStyle)
+
+	for i, m := range members {
+		t.Row(strconv.Itoa(i+1), lb.getElapsed(m, 1), lb.getElapsed(m, 2), m.GetDisplayName())
+	}
+
+	return "Time taken since the puzzle unlocked\n" + t.Render()
+}
+
+// Formats the time between the puzzle unlocking and the member getting the star
+func (lb *PrivateLeaderboard) getElapsed(m *PrivateMember, part int) string {
+	starTime, ok := m.GetStarTime(lb.Day, part)
+	if !ok {
+		return "-"
+	}
+
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		loc = time.UTC
+	}
+
+	elapsed := starTime.Sub(time.Date(lb.Year, time.December, lb.Day, 0, 0, 0, 0, loc))
+	if elapsed >= 24*time.Hour {
+		return ">24h"
+	}
+
+	elapsed = elapsed.Round(time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", int(elapsed.Hours()), int(elapsed.Minutes())%60, int(elapsed.Seconds())%60)
+}
+
+func getPrivateBucketID(year int, id string) string {
+	return "private" + strconv.Itoa(year) + "-" + id
+}
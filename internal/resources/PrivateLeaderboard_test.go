@@ -0,0 +1,59 @@
+package resources
+
+import (
+	"testing"
+)
+
+const testPrivateLB = `{
+	"event": "2023",
+	"owner_id": 1,
+	"members": {
+		"1": {"id": 1, "name": "Owner", "stars": 3, "local_score": 10, "global_score": 0, "last_star_ts": 1701410000,
+			"completion_day_level": {"1": {"1": {"get_star_ts": 1701407000, "star_index": 10}, "2": {"get_star_ts": 1701410000, "star_index": 20}},
+				"2": {"1": {"get_star_ts": 1701500000, "star_index": 30}}}},
+		"2": {"id": 2, "name": null, "stars": 2, "local_score": 12, "global_score": 0, "last_star_ts": 1701408000,
+			"completion_day_level": {"1": {"1": {"get_star_ts": 1701406900, "star_index": 5}, "2": {"get_star_ts": 1701408000, "star_index": 15}}}},
+		"3": {"id": 3, "name": "Lurker", "stars": 0, "local_score": 0, "global_score": 0, "last_star_ts": 0,
+			"completion_day_level": {}}
+	}
+}`
+
+func TestParsePrivateLeaderboard(t *testing.T) {
+	lb := &PrivateLeaderboard{Year: 2023, ID: "1"}
+	if err := lb.parseMembers([]byte(testPrivateLB)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(lb.Members) != 3 {
+		t.Fatalf("Expected 3 members, got %d", len(lb.Members))
+	}
+
+	owner := lb.Members["1"]
+	starTime, ok := owner.GetStarTime(1, 2)
+	if !ok || starTime.Unix() != 1701410000 {
+		t.Errorf("Expected day 1 part 2 star at 1701410000, got %v (found: %v)", starTime.Unix(), ok)
+	}
+
+	if _, ok := owner.GetStarTime(2, 2); ok {
+		t.Errorf("Expected no day 2 part 2 star")
+	}
+
+	if name := lb.Members["2"].GetDisplayName(); name != "(anonymous user #2)" {
+		t.Errorf("Expected anonymous display name, got %v", name)
+	}
+
+	sorted := lb.SortedMembers()
+	for i, expectedID := range []int{2, 1, 3} {
+		if sorted[i].ID != expectedID {
+			t.Errorf("Position %d: expected member %d, got %d", i+1, expectedID, sorted[i].ID)
+		}
+	}
+}
+
+func TestParsePrivateLeaderboardNotMember(t *testing.T) {
+	lb := &PrivateLeaderboard{Year: 2023, ID: "1"}
+	err := lb.parseMembers([]byte("<!DOCTYPE html><html></html>"))
+	if err == nil {
+		t.Fatalf("Expected error when parsing an HTML page")
+	}
+}
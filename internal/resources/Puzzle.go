@@ -2,8 +2,10 @@ package resources
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
@@ -17,11 +19,17 @@ import (
 	"github.com/PuerkitoBio/goquery"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/mattn/go-runewidth"
 )
 
-// Base URL for a single day's puzzle
-const PUZZLE_URL = "https://adventofcode.com/%v/day/%v"
+var (
+	// ErrInvalidToken is returned when the site doesn't accept the session token
+	ErrInvalidToken = errors.New("Session token appears to be invalid. Login in a browser and get your new token.")
+
+	// ErrPuzzleLocked is returned when requesting a puzzle that hasn't unlocked yet
+	ErrPuzzleLocked = errors.New("Puzzle hasn't unlocked yet")
+)
 
 // Puzzle represents a single day's puzzle.
 // Consists of user info as well as page display info.
@@ -42,6 +50,9 @@ type Puzzle struct {
 	UserInput   []byte
 	Submissions map[int][]*Submission
 
+	// Example inputs and answers found on the page for each part
+	Examples map[int][]Example
+
 	LockoutEnd time.Time
 }
 
@@ -53,43 +64,67 @@ func (p *Puzzle) SaveResource()                { cache.SaveResource(p) }
 // LoadOrCreatePuzzle attempts to load the requested puzzle from
 // storage. If it's unable to be loaded, it will attempt to be
 // created, loading the information from the website.
-func LoadOrCreatePuzzle(year int, day int, userSession string) *Puzzle {
+func LoadOrCreatePuzzle(year int, day int, userSession string) (*Puzzle, error) {
 	bucketID := strconv.Itoa(year) + strconv.Itoa(day)
-	puzzleData := cache.LoadResource(cache.PUZZLES, bucketID)
+	puzzleData, err := loadFromCache(cache.PUZZLES, bucketID)
+	if err != nil {
+		return nil, err
+	}
 
 	if puzzleData != nil {
 		var puzzle *Puzzle
 		json.Unmarshal(puzzleData, &puzzle)
-		return puzzle
+		puzzle.cleanAnswers()
+		return puzzle, nil
 	}
 
 	return newPuzzle(year, day, userSession)
 }
 
+// LoadCachedPuzzle loads a puzzle from storage without ever contacting the site.
+// Returns nil if the puzzle hasn't been stored.
+func LoadCachedPuzzle(year int, day int) *Puzzle {
+	puzzleData := cache.LoadStaleResource(cache.PUZZLES, strconv.Itoa(year)+strconv.Itoa(day))
+	if puzzleData == nil {
+		return nil
+	}
+
+	var puzzle *Puzzle
+	if err := json.Unmarshal(puzzleData, &puzzle); err != nil {
+		return nil
+	}
+	puzzle.cleanAnswers()
+	return puzzle
+}
+
+// Puzzles cached by older versions stored their answers already styled for the terminal
+func (p *Puzzle) cleanAnswers() {
+	p.AnswerOne = strings.TrimSpace(ansi.Strip(p.AnswerOne))
+	p.AnswerTwo = strings.TrimSpace(ansi.Strip(p.AnswerTwo))
+}
+
 // Displays the puzzle's page to the user
 func (p *Puzzle) Display() {
 	NewPuzzleViewport(p)
 }
 
-// Answer Response types
-const (
-	IncorrectAnswer int = iota
-	CorrectAnswer
-	WarningAnswer // Not submitted, but weird behavior
-	NeutralAnswer // Not submitted, but no warning
-)
-
 // SubmitAnswer takes an answer and a part to submit to.
 // If no part is provided, it will be derived based on stored puzzle information.
-func (p *Puzzle) SubmitAnswer(answer string, part int) (int, string) {
-	if !time.Now().After(p.LockoutEnd) {
-		return WarningAnswer, fmt.Sprintf("Still within lockout period of last submission. Lockout End: %s", p.LockoutEnd.Format(time.Stamp))
+// Answers that can't be correct based on stored information are blocked without being submitted.
+func (p *Puzzle) SubmitAnswer(answer string, part int) (SubmissionResult, error) {
+	if wait := time.Until(p.LockoutEnd); wait > 0 {
+		return SubmissionResult{
+			Outcome: OutcomeRateLimited,
+			Message: fmt.Sprintf("Still within lockout period of last submission. Lockout End: %s", p.LockoutEnd.Format(time.Stamp)),
+			Wait:    wait,
+		}, nil
 	}
 
+	answer = strings.TrimSpace(answer)
 	if p.AnswerOne != "" && answer == p.AnswerOne {
-		return NeutralAnswer, "Correct answer for Part 1 (no answer submitted, already got star)."
+		return SubmissionResult{Outcome: OutcomeAlreadySolved, Message: "Correct answer for Part 1 (no answer submitted, already got star)."}, nil
 	} else if p.AnswerTwo != "" && answer == p.AnswerTwo {
-		return NeutralAnswer, "Correct answer for Part 2 (no answer submitted, already got star)."
+		return SubmissionResult{Outcome: OutcomeAlreadySolved, Message: "Correct answer for Part 2 (no answer submitted, already got star)."}, nil
 	}
 
 	if part == 0 {
@@ -98,82 +133,97 @@ func (p *Puzzle) SubmitAnswer(answer string, part int) (int, string) {
 		} else if p.AnswerTwo == "" {
 			part = 2
 		} else {
-			return NeutralAnswer, "You've already gotten both stars for this level."
+			return SubmissionResult{Outcome: OutcomeAlreadySolved, Message: "You've already gotten both stars for this level."}, nil
 		}
 	}
 
-	// TODO: Check past submissions before allowing submission
-	//	- Past submissions
-	//		- Too high / too low
-
 	for _, pastSub := range p.Submissions[part] {
-		if pastSub.answer == answer {
-			return WarningAnswer, "You've already submitted that answer!"
+		if pastSub.Answer == answer {
+			return SubmissionResult{Outcome: OutcomeDuplicate, Message: "You've already submitted that answer!"}, nil
+		}
+	}
+
+	if num, err := strconv.ParseInt(strings.TrimSpace(answer), 10, 64); err == nil {
+		bounds := GetAnswerBounds(p.Submissions[part])
+		if !bounds.Contains(num) {
+			return SubmissionResult{Outcome: OutcomeOutOfBounds, Message: fmt.Sprintf("Based on past submissions, the answer must be %v.", bounds)}, nil
 		}
 	}
 
 	submissionData, err := api.SubmitAnswer(p.Year, p.Day, part, p.SessionToken, answer)
-	if err != nil {
-		log.Fatal(err)
+	if errors.Is(err, api.ErrRateLimited) {
+		return SubmissionResult{Outcome: OutcomeRateLimited, Message: "The site is rate limiting requests, try again later.", Submitted: true}, nil
+	} else if err != nil {
+		return SubmissionResult{}, err
 	}
 
-	submission, err := NewSubmission(submissionData, answer)
+	submission, err := NewSubmission(submissionData, answer, part)
 	if err != nil {
-		log.Fatal(err)
+		return SubmissionResult{}, err
+	}
+
+	result := SubmissionResult{
+		Outcome:   submission.Outcome,
+		Message:   submission.Message,
+		Wait:      submission.Lockout,
+		Submitted: true,
+	}
+
+	defer p.SaveResource()
+	if result.Wait > 0 {
+		p.LockoutEnd = submission.When.Add(result.Wait)
+	}
+
+	// Only answers the site actually judged are worth remembering
+	if !submission.Correct && !submission.Outcome.IsIncorrect() {
+		return result, nil
 	}
 
 	if p.Submissions == nil {
 		p.Submissions = make(map[int][]*Submission)
 	}
+	p.Submissions[part] = append(p.Submissions[part], submission)
 
-	outList, ok := p.Submissions[part]
-	if !ok {
-		outList = make([]*Submission, 0)
+	if !submission.Correct {
+		return result, nil
 	}
 
-	outList = append(outList, submission)
-
-	p.Submissions[part] = outList
-	defer p.SaveResource()
-	if submission.correct {
-		defer p.ReloadPuzzleData()
+	defer p.ReloadPuzzleData()
 
-		if p.AnswerOne == "" {
-			p.AnswerOne = answer
-			if p.Day == 25 {
-				p.AnswerTwo = "Merry Christmas!"
-				return CorrectAnswer, "If you've got all 49 other stars for this year, submit again to get the 50th and complete the year!"
-			} else {
-				return CorrectAnswer, "First star obtained! Run `view` again to get part 2."
-			}
+	if p.AnswerOne == "" {
+		p.AnswerOne = answer
+		if calendar := GetEventCalendar(p.Year); calendar.IsFinalDay(p.Day) {
+			p.AnswerTwo = FINAL_STAR_ANSWER
+			result.Message = fmt.Sprintf("If you've got all %v other stars for this year, submit again to get the %vth and complete the year!",
+				calendar.TotalStars()-1, calendar.TotalStars())
 		} else {
-			p.AnswerTwo = answer
-			return CorrectAnswer, "Second star obtained! That's all for today, good luck tomorrow!"
+			result.Message = "First star obtained! Run `view` again to get part 2."
 		}
-
 	} else {
-		lockoutDuration, err := utils.ParseDuration(submission.message)
-		if err != nil {
-			return IncorrectAnswer, submission.message + "\nUnable to parse lockout duration from message."
-		}
-
-		p.LockoutEnd = time.Now().Add(lockoutDuration)
-
-		return IncorrectAnswer, submission.message
+		p.AnswerTwo = answer
+		result.Message = "Second star obtained! That's all for today, good luck tomorrow!"
 	}
+
+	return result, nil
 }
 
 // Creates a new puzzle by loading information from the server. Bypasses any cached data
-func newPuzzle(year int, day int, userSession string) *Puzzle {
-	URL := fmt.Sprintf(PUZZLE_URL, year, day)
+func newPuzzle(year int, day int, userSession string) (*Puzzle, error) {
+	URL := api.DayURL(year, day)
 	bucketID := strconv.Itoa(year) + strconv.Itoa(day)
 
-	userInput, err := loadUserInputFromSite(URL, userSession)
+	if err := utils.CheckDay(year, day); err != nil {
+		return nil, err
+	}
 
+	maxYear, maxDay := utils.GetCurrentMaxYearAndDay()
+	if year > maxYear || (year == maxYear && day > maxDay) {
+		return nil, ErrPuzzleLocked
+	}
+
+	userInput, err := loadUserInputFromSite(URL, userSession)
 	if err != nil {
-		log.Fatal("Unable to load user input for the puzzle.", "error", err)
-	} else if strings.Contains(string(userInput), "log in") {
-		log.Fatal("Session token appears to be invalid. Login in a browser and get your new token.")
+		return nil, fmt.Errorf("Unable to load user input for the puzzle: %w", err)
 	}
 
 	subMap := make(map[int][]*Submission)
@@ -190,10 +240,12 @@ func newPuzzle(year int, day int, userSession string) *Puzzle {
 		Submissions:  subMap,
 	}
 
-	newPuzzle.loadPageData()
+	if err := newPuzzle.loadPageData(); err != nil {
+		return nil, err
+	}
 	newPuzzle.SaveResource()
 
-	return newPuzzle
+	return newPuzzle, nil
 }
 
 // Reloads puzzle information from the server
@@ -204,7 +256,9 @@ func (p *Puzzle) ReloadPuzzleData() error {
 	}
 
 	p.UserInput = newInput
-	p.loadPageData()
+	if err := p.loadPageData(); err != nil {
+		return err
+	}
 	p.SaveResource()
 	return nil
 }
@@ -223,6 +277,15 @@ func (p *Puzzle) GetUserInput() ([]byte, error) {
 	return input, nil
 }
 
+// GetName returns the puzzle's name without the day, like "Not Quite Lisp" for "--- Day 1: Not Quite Lisp ---"
+func (p *Puzzle) GetName() string {
+	name := strings.TrimSpace(strings.Trim(strings.TrimSpace(p.Title), "-"))
+	if _, after, found := strings.Cut(name, ":"); found {
+		name = after
+	}
+	return strings.TrimSpace(name)
+}
+
 // GetPrettyPageData parses the puzzle's stored information and displays it in a visually pleasing way.
 func (p *Puzzle) GetPrettyPageData() []string {
 	var sOut []string
@@ -232,7 +295,7 @@ func (p *Puzzle) GetPrettyPageData() []string {
 	sOut = append(sOut, p.ArticleOne...)
 
 	if p.AnswerOne != "" {
-		sOut = append(sOut, "Answer: "+p.AnswerOne)
+		sOut = append(sOut, "Answer: "+styles.CodeStyle.Render(p.AnswerOne))
 	}
 
 	if len(p.ArticleTwo) != 0 {
@@ -242,7 +305,7 @@ func (p *Puzzle) GetPrettyPageData() []string {
 		sOut = append(sOut, "\n")
 
 		if p.AnswerTwo != "" {
-			sOut = append(sOut, "Answer: "+p.AnswerTwo)
+			sOut = append(sOut, "Answer: "+styles.CodeStyle.Render(p.AnswerTwo)+"\n")
 		}
 	}
 	return sOut
@@ -256,30 +319,42 @@ func loadUserInputFromSite(URL, userSession string) ([]byte, error) {
 	}
 	defer resp.Body.Close()
 
+	// Locked puzzles 404, and missing or invalid tokens are told to log in
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrPuzzleLocked
+	}
+
 	inputData, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
 	}
 
+	if resp.StatusCode == http.StatusBadRequest || strings.Contains(string(inputData), "log in") {
+		return nil, ErrInvalidToken
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Unexpected response from the site: %v", resp.Status)
+	}
+
 	return inputData, nil
 }
 
 // loadPageData will make the HTTP request and pass it off to be parsed.
-func (p *Puzzle) loadPageData() {
+func (p *Puzzle) loadPageData() error {
 	resp, err := api.NewGetReq(p.URL, p.SessionToken)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	defer resp.Body.Close()
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
-		log.Fatal("Error constructing new PageData.", "error", err)
+		return fmt.Errorf("Error constructing new PageData: %w", err)
 	}
 
 	mainContents := doc.Find("main")
 
 	p.processPageContents(mainContents)
+	return nil
 }
 
 // processPageContents will go through the <main> tag
@@ -294,15 +369,25 @@ func (p *Puzzle) processPageContents(mainContents *goquery.Selection) {
 
 	p.Title = mainContents.Find("h2").First().Text()
 
+	p.Examples = make(map[int][]Example)
+	var partTwoAnswer string
+
 	mainContents.Find("article").Each(func(i int, s *goquery.Selection) {
 		if len(p.ArticleOne) == 0 {
 			p.ArticleOne = getPrettyArticle(s)
+			p.Examples[1], _ = parseExamples(s)
 
 		} else {
 			p.ArticleTwo = getPrettyArticle(s)
+			p.Examples[2], partTwoAnswer = parseExamples(s)
 		}
 	})
 
+	// Part two usually reuses part one's example, only giving a new answer for it
+	if len(p.Examples[2]) == 0 && partTwoAnswer != "" && len(p.Examples[1]) > 0 {
+		p.Examples[2] = []Example{{Input: p.Examples[1][0].Input, Expected: partTwoAnswer}}
+	}
+
 	// This should only grab "Your puzzle answer was: " tags
 	mainContents.Find("article + p").Each(func(i int, s *goquery.Selection) {
 		if strings.Contains(s.Text(), "answer") {
@@ -310,10 +395,10 @@ func (p *Puzzle) processPageContents(mainContents *goquery.Selection) {
 			if outStr != "" {
 				if p.AnswerOne == "" {
 					log.Debug("Answer found!", "year", p.Year, "day", p.Day, "answer", outStr)
-					p.AnswerOne = styles.CodeStyle.Render(outStr)
+					p.AnswerOne = outStr
 				} else {
 					log.Debug("Answer found!", "year", p.Year, "day", p.Day, "answer", outStr)
-					p.AnswerTwo = styles.CodeStyle.Render(outStr) + "\n"
+					p.AnswerTwo = outStr
 				}
 			}
 		}
@@ -335,7 +420,6 @@ func getPrettyArticle(article *goquery.Selection) []string {
 			sel.Find("li").Each(func(j int, s *goquery.Selection) {
 				articleOut = append(articleOut, " - "+wrapText(getPrettySelection(s), ViewportWidth-2)+"\n\n")
 			})
-			articleOut = append(articleOut)
 		case "pre":
 			// Extract the <code> content
 			preContent := sel.Find("code").Text()
@@ -0,0 +1,238 @@
+package resources
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.dalton.dog/aocgo/aocfake"
+	"go.dalton.dog/aocgo/internal/api"
+	"go.dalton.dog/aocgo/internal/cache"
+)
+
+// Starts a fake server and points the API client and cache at it
+func startFakeServer(t *testing.T) *aocfake.Server {
+	fake := aocfake.New()
+	server := httptest.NewServer(fake)
+
+	backupCacheFile := cache.CacheFile
+	cache.CacheFile = filepath.Join(t.TempDir(), "%v.db")
+	if err := cache.StartupDBM(fake.Token); err != nil {
+		t.Fatalf("Unable to start database: %v", err)
+	}
+
+	api.InitClient(fake.Token, api.WithBaseURL(server.URL))
+
+	t.Cleanup(func() {
+		cache.ShutdownDBM()
+		cache.CacheFile = backupCacheFile
+		server.Close()
+		api.MasterClient = nil
+	})
+
+	return fake
+}
+
+func TestLoadPuzzle(t *testing.T) {
+	fake := startFakeServer(t)
+
+	puzzle, err := LoadOrCreatePuzzle(2015, 1, fake.Token)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if puzzle.Title != "--- Day 1: Counting Sheep ---" {
+		t.Errorf("Unexpected title %v", puzzle.Title)
+	}
+
+	if len(puzzle.ArticleOne) == 0 || len(puzzle.ArticleTwo) != 0 {
+		t.Errorf("Expected only part one's article, got %d and %d lines", len(puzzle.ArticleOne), len(puzzle.ArticleTwo))
+	}
+
+	input, err := puzzle.GetUserInput()
+	if err != nil || string(input) != fake.GetPuzzle(2015, 1).Input {
+		t.Errorf("Puzzle input doesn't match the server's (err: %v)", err)
+	}
+
+	if examples := puzzle.GetExamples(1); len(examples) != 1 || examples[0] != (Example{Input: "3\n1\n4\n1\n5", Expected: "14"}) {
+		t.Errorf("Unexpected examples for part one: %+v", examples)
+	}
+}
+
+func TestLoadPuzzleErrors(t *testing.T) {
+	fake := startFakeServer(t)
+
+	if _, err := LoadOrCreatePuzzle(2015, 3, fake.Token); !errors.Is(err, ErrPuzzleLocked) {
+		t.Errorf("Expected ErrPuzzleLocked, got %v", err)
+	}
+
+	if _, err := LoadOrCreatePuzzle(2015, 1, "not-the-token"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestSubmitAnswer(t *testing.T) {
+	fake := startFakeServer(t)
+
+	puzzle, err := LoadOrCreatePuzzle(2015, 2, fake.Token)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	puzzle.SessionToken = fake.Token
+
+	result, err := puzzle.SubmitAnswer("100", 1)
+	if err != nil || result.Outcome != OutcomeTooHigh || !result.Submitted {
+		t.Errorf("Expected an incorrect answer that's too high, got %+v (err: %v)", result, err)
+	}
+
+	if !puzzle.LockoutEnd.After(time.Now()) {
+		t.Errorf("Expected a lockout after a wrong answer, got %v", puzzle.LockoutEnd)
+	}
+
+	if hint := puzzle.Submissions[1][0].Hint; hint != TooHigh {
+		t.Errorf("Expected the submission to be recorded as too high, got %v", hint)
+	}
+
+	result, _ = puzzle.SubmitAnswer("91", 1)
+	if result.Outcome != OutcomeRateLimited || result.Submitted || result.Wait <= 0 {
+		t.Errorf("Expected submission to be blocked by the lockout, got %+v", result)
+	}
+
+	puzzle.LockoutEnd = time.Time{}
+	fake.SetLockout(0)
+
+	result, _ = puzzle.SubmitAnswer("150", 1)
+	if result.Outcome != OutcomeOutOfBounds || !strings.Contains(result.Message, "less than 100") {
+		t.Errorf("Expected submission to be blocked by the known bounds, got %+v", result)
+	}
+
+	result, err = puzzle.SubmitAnswer("91", 1)
+	if err != nil || result.Outcome != OutcomeCorrect {
+		t.Fatalf("Expected a correct answer, got %+v (err: %v)", result, err)
+	}
+
+	if fake.GetPuzzle(2015, 2).Solved != 1 {
+		t.Errorf("Expected the server to record the first star")
+	}
+
+	if len(puzzle.ArticleTwo) == 0 {
+		t.Errorf("Expected part two to be loaded after getting the first star")
+	}
+
+	if examples := puzzle.GetExamples(2); len(examples) != 1 || examples[0] != (Example{Input: "abc\nxyz\naxa\nbyc", Expected: "3"}) {
+		t.Errorf("Expected part two to reuse part one's example, got %+v", examples)
+	}
+}
+
+func TestSubmitAnswerTooRecently(t *testing.T) {
+	fake := startFakeServer(t)
+
+	puzzle, err := LoadOrCreatePuzzle(2015, 2, fake.Token)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	fake.SetLockout(30 * time.Second)
+
+	result, err := puzzle.SubmitAnswer("91", 1)
+	if err != nil || result.Outcome != OutcomeRateLimited || !result.Submitted {
+		t.Errorf("Expected to be told the answer was too recent, got %+v (err: %v)", result, err)
+	}
+
+	if len(puzzle.Submissions[1]) != 0 {
+		t.Errorf("Expected an answer that wasn't judged to not be recorded")
+	}
+
+	if wait := time.Until(puzzle.LockoutEnd); wait < 25*time.Second || wait > 30*time.Second {
+		t.Errorf("Expected a 30 second lockout, got %v", wait)
+	}
+}
+
+func TestSubmissionHistoryPersists(t *testing.T) {
+	fake := startFakeServer(t)
+
+	puzzle, err := LoadOrCreatePuzzle(2015, 2, fake.Token)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result, err := puzzle.SubmitAnswer("100", 1); err != nil || result.Outcome != OutcomeTooHigh {
+		t.Fatalf("Expected an incorrect answer, got %+v (err: %v)", result, err)
+	}
+
+	stored := LoadCachedPuzzle(2015, 2)
+	if stored == nil || len(stored.Submissions[1]) != 1 {
+		t.Fatalf("Expected the submission to be stored with the puzzle, got %+v", stored)
+	}
+
+	sub := stored.Submissions[1][0]
+	if sub.Answer != "100" || sub.Part != 1 || sub.Correct || sub.Hint != TooHigh ||
+		sub.Lockout != aocfake.WRONG_ANSWER_LOCKOUT || sub.When.IsZero() || sub.Message == "" {
+		t.Errorf("Submission wasn't stored correctly, got %+v", sub)
+	}
+
+	stored.LockoutEnd = time.Time{}
+	fake.SetLockout(0)
+
+	result, _ := stored.SubmitAnswer("100", 1)
+	if result.Outcome != OutcomeDuplicate || result.Submitted {
+		t.Errorf("Expected a stored duplicate answer to be blocked, got %+v", result)
+	}
+}
+
+func TestSubmitAnswerAlreadySolved(t *testing.T) {
+	fake := startFakeServer(t)
+	fake.GetPuzzle(2015, 2).Solved = 2
+
+	if _, err := LoadOrCreatePuzzle(2015, 2, fake.Token); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Any answer that reaches the site is counted
+	posts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posts++
+		}
+		fake.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	api.InitClient(fake.Token, api.WithBaseURL(server.URL))
+
+	puzzle := LoadCachedPuzzle(2015, 2)
+	if puzzle == nil || puzzle.AnswerOne != "91" || puzzle.AnswerTwo != "16" {
+		t.Fatalf("Expected the cached puzzle to have both answers, got %+v", puzzle)
+	}
+
+	for part, answer := range []string{"91", "16"} {
+		result, err := puzzle.SubmitAnswer(answer, 0)
+		if err != nil || result.Outcome != OutcomeAlreadySolved || result.Submitted {
+			t.Errorf("Expected part %d's known answer to be blocked, got %+v (err: %v)", part+1, result, err)
+		}
+	}
+
+	if posts != 0 {
+		t.Errorf("Expected no answers to be sent to the site, got %d", posts)
+	}
+}
+
+func TestGetName(t *testing.T) {
+	var tests = []struct {
+		title, name string
+	}{
+		{"--- Day 1: Not Quite Lisp ---", "Not Quite Lisp"},
+		{"--- Day 15: Science for Hungry People: Part Two ---", "Science for Hungry People: Part Two"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		puzzle := &Puzzle{Title: test.title}
+		if name := puzzle.GetName(); name != test.name {
+			t.Errorf("Expected %q to be named %q, got %q", test.title, test.name, name)
+		}
+	}
+}
@@ -1,41 +1,118 @@
 package resources
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
 )
 
+// Hint is the site's feedback on which direction an incorrect answer was off in
+type Hint string
+
+const (
+	NoHint  Hint = ""
+	TooHigh Hint = "too high"
+	TooLow  Hint = "too low"
+)
+
+// Submission is a single answer submitted to the site, stored with its puzzle
 type Submission struct {
-	answer  string
-	when    time.Time
-	correct bool
-	message string
+	Answer  string
+	Part    int
+	When    time.Time
+	Outcome Outcome
+	Correct bool
+	Hint    Hint
+	Message string
+
+	// How long the site locked out further submissions after a wrong answer
+	Lockout time.Duration
 }
 
-func NewSubmission(data *http.Response, answer string) (*Submission, error) {
+// NewSubmission creates a submission from the site's response to submitting an answer
+func NewSubmission(data *http.Response, answer string, part int) (*Submission, error) {
 	defer data.Body.Close()
 	doc, err := goquery.NewDocumentFromReader(data.Body)
 	if err != nil {
 		return nil, err
 	}
 
+	// Locked puzzles and the login page don't have an article to read the response from
 	message := doc.Find("article").Text()
+	if message == "" {
+		message = doc.Text()
+	}
+
+	result := ParseSubmissionResult(message)
+
+	return &Submission{
+		When:    time.Now(),
+		Answer:  answer,
+		Part:    part,
+		Outcome: result.Outcome,
+		Correct: result.Outcome == OutcomeCorrect,
+		Hint:    result.Outcome.Hint(),
+		Message: result.Message,
+		Lockout: result.Wait,
+	}, nil
+}
 
-	newSub := &Submission{
-		when:    time.Now(),
-		answer:  answer,
-		message: message,
+// AnswerBounds are the exclusive bounds a numeric answer has to fall within,
+// based on answers that were previously too high or too low
+type AnswerBounds struct {
+	Lower    int64
+	HasLower bool
+	Upper    int64
+	HasUpper bool
+}
+
+// GetAnswerBounds finds the tightest bounds from a list of past submissions
+func GetAnswerBounds(submissions []*Submission) AnswerBounds {
+	var bounds AnswerBounds
+	for _, sub := range submissions {
+		num, err := strconv.ParseInt(strings.TrimSpace(sub.Answer), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		switch sub.Hint {
+		case TooLow:
+			if !bounds.HasLower || num > bounds.Lower {
+				bounds.Lower = num
+				bounds.HasLower = true
+			}
+		case TooHigh:
+			if !bounds.HasUpper || num < bounds.Upper {
+				bounds.Upper = num
+				bounds.HasUpper = true
+			}
+		}
 	}
+	return bounds
+}
 
-	if strings.Contains(message, "That's the right answer!") ||
-		strings.Contains(message, "Congratulations!") {
-		newSub.correct = true
-	} else {
-		newSub.correct = false
+// Contains returns true if the answer could be correct
+func (b AnswerBounds) Contains(answer int64) bool {
+	if b.HasLower && answer <= b.Lower {
+		return false
 	}
+	if b.HasUpper && answer >= b.Upper {
+		return false
+	}
+	return true
+}
 
-	return newSub, nil
+func (b AnswerBounds) String() string {
+	if b.HasLower && b.HasUpper {
+		return fmt.Sprintf("greater than %v and less than %v", b.Lower, b.Upper)
+	} else if b.HasLower {
+		return fmt.Sprintf("greater than %v", b.Lower)
+	} else if b.HasUpper {
+		return fmt.Sprintf("less than %v", b.Upper)
+	}
+	return "anything"
 }
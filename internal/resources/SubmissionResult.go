@@ -0,0 +1,114 @@
+package resources
+
+import (
+	"strings"
+	"time"
+
+	"go.dalton.dog/aocgo/internal/utils"
+)
+
+// Outcome is what happened when an answer was submitted
+type Outcome int
+
+const (
+	OutcomeUnknown Outcome = iota
+	OutcomeCorrect
+	OutcomeWrong
+	OutcomeTooHigh
+	OutcomeTooLow
+	OutcomeRateLimited
+	OutcomeWrongLevel
+	OutcomeAlreadySolved
+	OutcomeNotLoggedIn
+	OutcomePuzzleLocked
+
+	// Only decided locally, these are never sent to the site
+	OutcomeDuplicate
+	OutcomeOutOfBounds
+)
+
+var outcomeNames = map[Outcome]string{
+	OutcomeUnknown:       "Unknown",
+	OutcomeCorrect:       "Correct",
+	OutcomeWrong:         "Wrong",
+	OutcomeTooHigh:       "Too high",
+	OutcomeTooLow:        "Too low",
+	OutcomeRateLimited:   "Rate limited",
+	OutcomeWrongLevel:    "Wrong level",
+	OutcomeAlreadySolved: "Already solved",
+	OutcomeNotLoggedIn:   "Not logged in",
+	OutcomePuzzleLocked:  "Puzzle locked",
+	OutcomeDuplicate:     "Duplicate",
+	OutcomeOutOfBounds:   "Out of bounds",
+}
+
+func (o Outcome) String() string {
+	if name, ok := outcomeNames[o]; ok {
+		return name
+	}
+	return outcomeNames[OutcomeUnknown]
+}
+
+// IsIncorrect returns true if the site judged the answer to be wrong
+func (o Outcome) IsIncorrect() bool {
+	return o == OutcomeWrong || o == OutcomeTooHigh || o == OutcomeTooLow
+}
+
+// Hint returns the too high / too low hint that comes with the outcome, if any
+func (o Outcome) Hint() Hint {
+	switch o {
+	case OutcomeTooHigh:
+		return TooHigh
+	case OutcomeTooLow:
+		return TooLow
+	default:
+		return NoHint
+	}
+}
+
+// SubmissionResult is the result of trying to submit an answer
+type SubmissionResult struct {
+	Outcome Outcome
+	Message string
+
+	// How long until another answer can be submitted, for wrong answers and rate limits
+	Wait time.Duration
+
+	// False if the answer was blocked before being sent to the site
+	Submitted bool
+}
+
+// ParseSubmissionResult decides the outcome of a submission from the text of the site's response
+func ParseSubmissionResult(message string) SubmissionResult {
+	message = strings.TrimSpace(message)
+	result := SubmissionResult{Message: message, Submitted: true}
+
+	switch {
+	case strings.Contains(message, "That's the right answer!"),
+		strings.Contains(message, "Congratulations!"):
+		result.Outcome = OutcomeCorrect
+	case strings.Contains(message, "your answer is too high"):
+		result.Outcome = OutcomeTooHigh
+	case strings.Contains(message, "your answer is too low"):
+		result.Outcome = OutcomeTooLow
+	case strings.Contains(message, "That's not the right answer"):
+		result.Outcome = OutcomeWrong
+	case strings.Contains(message, "You gave an answer too recently"):
+		result.Outcome = OutcomeRateLimited
+	case strings.Contains(message, "You don't seem to be solving the right level"):
+		result.Outcome = OutcomeWrongLevel
+	case strings.Contains(message, "before it unlocks"):
+		result.Outcome = OutcomePuzzleLocked
+	case strings.Contains(message, "please identify yourself"),
+		strings.Contains(message, "log in"):
+		result.Outcome = OutcomeNotLoggedIn
+	}
+
+	if result.Outcome.IsIncorrect() || result.Outcome == OutcomeRateLimited {
+		if wait, err := utils.ParseDuration(message); err == nil {
+			result.Wait = wait
+		}
+	}
+
+	return result
+}
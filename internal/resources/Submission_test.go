@@ -0,0 +1,71 @@
+package resources
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSubmissionResult(t *testing.T) {
+	var tests = []struct {
+		message string
+		outcome Outcome
+		wait    time.Duration
+	}{
+		{"That's the right answer!  You are one gold star closer to saving Christmas.", OutcomeCorrect, 0},
+		{"Congratulations!  You've finished every puzzle in Advent of Code 2015!", OutcomeCorrect, 0},
+		{"That's not the right answer; your answer is too high.  If you're stuck, ... Please wait one minute before trying again.", OutcomeTooHigh, time.Minute},
+		{"That's not the right answer; your answer is too low.  If you're stuck, ... Please wait 5 minutes before trying again.", OutcomeTooLow, 5 * time.Minute},
+		{"That's not the right answer.  If you're stuck, ... Please wait one minute before trying again.", OutcomeWrong, time.Minute},
+		{"You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 30s left to wait.", OutcomeRateLimited, 30 * time.Second},
+		{"You don't seem to be solving the right level.  Did you already complete it?", OutcomeWrongLevel, 0},
+		{"To play, please identify yourself via one of these services:", OutcomeNotLoggedIn, 0},
+		{"Please don't repeatedly request this endpoint before it unlocks!", OutcomePuzzleLocked, 0},
+		{"Something new", OutcomeUnknown, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.message, func(t *testing.T) {
+			result := ParseSubmissionResult(test.message)
+			if result.Outcome != test.outcome || result.Wait != test.wait {
+				t.Errorf("Expected %v (wait %v), got %v (wait %v)", test.outcome, test.wait, result.Outcome, result.Wait)
+			}
+		})
+	}
+}
+
+func TestGetAnswerBounds(t *testing.T) {
+	submissions := []*Submission{
+		{Answer: "50", Hint: TooLow},
+		{Answer: "200", Hint: TooHigh},
+		{Answer: "75", Hint: TooLow},
+		{Answer: "150", Hint: TooHigh},
+		{Answer: "100", Hint: NoHint},
+		{Answer: "abc", Hint: TooHigh},
+	}
+
+	bounds := GetAnswerBounds(submissions)
+	if !bounds.HasLower || bounds.Lower != 75 || !bounds.HasUpper || bounds.Upper != 150 {
+		t.Fatalf("Expected bounds of (75, 150), got %+v", bounds)
+	}
+
+	var tests = []struct {
+		answer   int64
+		possible bool
+	}{
+		{75, false},
+		{76, true},
+		{149, true},
+		{150, false},
+		{1000, false},
+	}
+
+	for _, test := range tests {
+		if possible := bounds.Contains(test.answer); possible != test.possible {
+			t.Errorf("Answer %v: expected possible to be %v, got %v", test.answer, test.possible, possible)
+		}
+	}
+
+	if bounds := GetAnswerBounds(nil); !bounds.Contains(-5) || bounds.String() != "anything" {
+		t.Errorf("Expected no bounds without submissions, got %+v", bounds)
+	}
+}
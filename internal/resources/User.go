@@ -2,10 +2,12 @@ package resources
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
 	"go.dalton.dog/aocgo/internal/api"
+	"go.dalton.dog/aocgo/internal/cache"
 	"go.dalton.dog/aocgo/internal/session"
 	"go.dalton.dog/aocgo/internal/utils"
 
@@ -49,7 +51,7 @@ func NewUser(token string) (*User, error) {
 
 	yearMap := make(map[int][]*Puzzle)
 	for i := utils.FIRST_YEAR; i <= time.Now().Year(); i++ {
-		yearMap[i] = make([]*Puzzle, 26)
+		yearMap[i] = make([]*Puzzle, GetEventCalendar(i).NumDays+1)
 	}
 
 	newUser := &User{
@@ -57,7 +59,10 @@ func NewUser(token string) (*User, error) {
 		Years:      yearMap,
 	}
 
-	name := newUser.LoadDisplayName()
+	name, err := newUser.LoadDisplayName()
+	if err != nil {
+		return nil, err
+	}
 	newUser.DisplayName = name
 
 	return newUser, nil
@@ -78,9 +83,15 @@ func (u *User) LoadUser() {
 
 	for year <= maxYear {
 		numStars[year] = 0
+		calendar := GetEventCalendar(year)
 		day := 1
-		for day <= 25 {
-			puzzle := LoadOrCreatePuzzle(year, day, u.SessionTok)
+		for day <= calendar.NumDays {
+			puzzle, err := LoadOrCreatePuzzle(year, day, u.SessionTok)
+			if err != nil {
+				log.Debug("Unable to load puzzle", "year", year, "day", day, "err", err)
+				day++
+				continue
+			}
 			u.Years[year][day] = puzzle
 
 			if puzzle.AnswerOne != "" {
@@ -94,9 +105,9 @@ func (u *User) LoadUser() {
 			day++
 		}
 
-		// There's only 1 puzzle on Day 25, so if they've earned 49 stars, they get the 50th for free
-		if numStars[year] == 49 {
-			u.Years[year][25].AnswerTwo = "Merry Christmas!"
+		// There's only 1 puzzle on the final day, so if they've earned every other star, they get the last one for free
+		if numStars[year] == calendar.TotalStars()-1 && u.Years[year][calendar.NumDays] != nil {
+			u.Years[year][calendar.NumDays].AnswerTwo = FINAL_STAR_ANSWER
 			u.NumStars++
 			numStars[year]++
 		}
@@ -104,16 +115,26 @@ func (u *User) LoadUser() {
 	}
 }
 
-func (u *User) LoadDisplayName() string {
-	resp, err := api.NewGetReq("https://adventofcode.com/", u.SessionTok)
+// LoadDisplayName loads the user's display name from the site.
+// While offline, the last display name that was loaded is used instead.
+func (u *User) LoadDisplayName() (string, error) {
+	if api.IsOffline() {
+		name, err := loadFromCache(cache.USER_DATA, cache.DISPLAY_NAME)
+		if err != nil {
+			return "", err
+		}
+		return string(name), nil
+	}
+
+	resp, err := api.NewGetReq("/", u.SessionTok)
 	if err != nil {
-		log.Fatal("Unable to load user's information", "err", err)
+		return "", fmt.Errorf("Unable to load user's information: %w", err)
 	}
 
 	defer resp.Body.Close()
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
-		log.Fatal("Error constructing new PageData.", "error", err)
+		return "", fmt.Errorf("Error constructing new PageData: %w", err)
 	}
 
 	nameDiv := doc.Find("div.user")
@@ -124,5 +145,11 @@ func (u *User) LoadDisplayName() string {
 	// log.Info(nameClone.Text())
 	nameClone.Find("span").Remove()
 
-	return strings.TrimSpace(nameClone.Text())
+	name := strings.TrimSpace(nameClone.Text())
+	if name == "" {
+		return "", ErrInvalidToken
+	}
+	cache.SaveGenericResource(cache.USER_DATA, cache.DISPLAY_NAME, []byte(name))
+
+	return name, nil
 }
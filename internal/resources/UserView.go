@@ -80,7 +80,7 @@ func (m LoadUserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case loadDoneMsg:
 		maxYear, _ := utils.GetCurrentMaxYearAndDay()
 		year, day := msg.year, msg.day
-		if day == 25 {
+		if GetEventCalendar(year).IsFinalDay(day) {
 			if year < maxYear {
 				m.curDate = 1
 				m.curYear++
@@ -136,22 +136,22 @@ func loadPuzzle(year, day int, userToken string) tea.Cmd {
 
 func generateTable(userToken string) tea.Cmd {
 	return func() tea.Msg {
-		maxYear, maxDay := utils.GetCurrentMaxYearAndDay()
+		maxYear, _ := utils.GetCurrentMaxYearAndDay()
+
+		headers := []string{"Year"}
+		for day := 1; day <= utils.MAX_DAYS; day++ {
+			headers = append(headers, fmt.Sprintf("%02d", day))
+		}
+		headers = append(headers, "Num")
 
 		t := table.New().
-			Headers("Year", "01", "02", "03", "04", "05", "06", "07", "08", "09",
-				"10", "11", "12", "13", "14", "15", "16", "17", "18", "19",
-				"20", "21", "22", "23", "24", "25", "Num").
+			Headers(headers...).
 			Border(lipgloss.NormalBorder()).
 			BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("99")))
 
 		y := utils.FIRST_YEAR
 		for y <= maxYear {
-			day := 25
-			if y == maxYear {
-				day = maxDay
-			}
-			t.Row(getRowForYear(userToken, y, day)...)
+			t.Row(getRowForYear(userToken, y, GetEventCalendar(y).UnlockedDays())...)
 			y++
 		}
 
@@ -160,18 +160,21 @@ func generateTable(userToken string) tea.Cmd {
 }
 
 func getRowForYear(userToken string, year, day int) []string {
-	stars := make([]string, 27)
+	calendar := GetEventCalendar(year)
+	stars := make([]string, utils.MAX_DAYS+2)
 	d := 1
 	numStars := 0
 
 	for d <= day {
-		p := LoadOrCreatePuzzle(year, d, userToken)
+		p, err := LoadOrCreatePuzzle(year, d, userToken)
 		var sOut string
-		if p.AnswerTwo != "" {
+		if err != nil {
+			sOut = lipgloss.NewStyle().Foreground(styles.NoStarsColor).Render("?")
+		} else if p.AnswerTwo != "" {
 			sOut = lipgloss.NewStyle().Foreground(styles.BothStarsColor).Render("*")
 			numStars += 2
 		} else if p.AnswerOne != "" {
-			if numStars == 48 {
+			if calendar.IsFinalDay(d) && numStars == calendar.TotalStars()-2 {
 				sOut = lipgloss.NewStyle().Foreground(styles.BothStarsColor).Render("*")
 				numStars += 2
 			} else {
@@ -185,14 +188,17 @@ func getRowForYear(userToken string, year, day int) []string {
 		d++
 	}
 
-	if day < 25 {
-		for d := day + 1; d <= 25; d++ {
+	// Days that haven't unlocked yet are marked, and days past the end of a shorter event are left blank
+	for d := day + 1; d <= utils.MAX_DAYS; d++ {
+		if calendar.HasDay(d) {
 			stars[d] = lipgloss.NewStyle().Foreground(styles.NoStarsColor).Render("-")
+		} else {
+			stars[d] = ""
 		}
 	}
 
 	stars[0] = strconv.Itoa(year)
-	stars[26] = strconv.Itoa(numStars)
+	stars[utils.MAX_DAYS+1] = strconv.Itoa(numStars)
 
 	return stars
 }
@@ -0,0 +1,22 @@
+package resources
+
+import (
+	"go.dalton.dog/aocgo/internal/api"
+	"go.dalton.dog/aocgo/internal/cache"
+)
+
+// loadFromCache loads a resource's stored data.
+// While offline, stale data is used, and data that was never stored is a cache.NotCachedError.
+func loadFromCache(bucketName, id string) ([]byte, error) {
+	data := cache.LoadResource(bucketName, id)
+	if data != nil || !api.IsOffline() {
+		return data, nil
+	}
+
+	data = cache.LoadStaleResource(bucketName, id)
+	if data == nil {
+		return nil, &cache.NotCachedError{Bucket: bucketName, ID: id}
+	}
+
+	return data, nil
+}
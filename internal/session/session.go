@@ -6,10 +6,24 @@ import (
 	"path/filepath"
 
 	"github.com/charmbracelet/log"
+	"go.dalton.dog/aocgo/internal/api"
 )
 
+// ErrNoToken is returned when a session token can't be found in any of the expected places
+var ErrNoToken = errors.New("Unable to load AoC session token from file or environment variable")
+
 // GetSessionToken attempts to get a valid session token.
+// The file is checked first, unless the API is pointed at another server, since its token won't work there.
 func GetSessionToken(healthLog bool) (string, error) {
+	if os.Getenv(api.BASE_URL_ENV) != "" {
+		if sessionToken, err := getTokenFromEnv(); sessionToken != "" {
+			if healthLog {
+				log.Info("Found session token in environment variable.", "token", sessionToken)
+			}
+			return sessionToken, err
+		}
+	}
+
 	sessionToken, err := getTokenFromFile("")
 	if sessionToken != "" {
 		if healthLog {
@@ -26,7 +40,7 @@ func GetSessionToken(healthLog bool) (string, error) {
 		return sessionToken, err
 	}
 
-	return "", errors.New("Unable to load AoC session token from file or environment variable")
+	return "", ErrNoToken
 }
 
 // Making this a separate function so it's testable
@@ -4,6 +4,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"go.dalton.dog/aocgo/internal/api"
 )
 
 func TestGetSessionTokenFromEnv(t *testing.T) {
@@ -65,3 +67,34 @@ func TestGetTokenFromFile(t *testing.T) {
 		t.Fatalf("Expected empty token, got %v", token)
 	}
 }
+
+func TestGetSessionTokenWithBaseURL(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	configDir := filepath.Join(homeDir, ".config", "aocgo")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Unable to create config directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "session.token"), []byte("file_token"), 0644); err != nil {
+		t.Fatalf("Unable to write session token file: %v", err)
+	}
+	t.Setenv("AOC_SESSION_TOKEN", "env_token")
+
+	// Test: The file wins against the real site
+	t.Setenv(api.BASE_URL_ENV, "")
+	if token, _ := GetSessionToken(false); token != "file_token" {
+		t.Fatalf("Expected token %v, got %v", "file_token", token)
+	}
+
+	// Test: The environment variable wins against another server
+	t.Setenv(api.BASE_URL_ENV, "http://localhost:8080")
+	if token, _ := GetSessionToken(false); token != "env_token" {
+		t.Fatalf("Expected token %v, got %v", "env_token", token)
+	}
+
+	// Test: The file is still used if there's no environment variable
+	t.Setenv("AOC_SESSION_TOKEN", "")
+	if token, _ := GetSessionToken(false); token != "file_token" {
+		t.Fatalf("Expected token %v, got %v", "file_token", token)
+	}
+}
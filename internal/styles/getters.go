@@ -38,6 +38,47 @@ func GetLeaderboardStyle(row, col int) lipgloss.Style {
 		style = lipgloss.NewStyle().Width(17).Align(lipgloss.Center)
 	}
 
+	return getPlacingColor(row, style)
+}
+
+func GetPrivateLeaderboardStyle(row, col int) lipgloss.Style {
+	if row == 0 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("99")).Bold(true).Align(lipgloss.Center)
+	}
+
+	var style lipgloss.Style
+	switch col {
+	case 0, 2:
+		style = lipgloss.NewStyle().Width(5).Align(lipgloss.Center)
+	case 1:
+		style = lipgloss.NewStyle().Width(7).Align(lipgloss.Center)
+	default:
+		style = lipgloss.NewStyle().Width(25)
+	}
+
+	return getPlacingColor(row, style)
+}
+
+func GetPrivateDailyStyle(row, col int) lipgloss.Style {
+	if row == 0 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("99")).Bold(true).Align(lipgloss.Center)
+	}
+
+	var style lipgloss.Style
+	switch col {
+	case 0:
+		style = lipgloss.NewStyle().Width(5).Align(lipgloss.Center)
+	case 1, 2:
+		style = lipgloss.NewStyle().Width(10).Align(lipgloss.Center)
+	default:
+		style = lipgloss.NewStyle().Width(40)
+	}
+
+	return getPlacingColor(row, style)
+}
+
+// Colors the top three rows of a leaderboard table
+func getPlacingColor(row int, style lipgloss.Style) lipgloss.Style {
 	if row == 1 {
 		return style.Foreground(GoldColor)
 	} else if row == 2 {
@@ -48,3 +89,48 @@ func GetLeaderboardStyle(row, col int) lipgloss.Style {
 		return style
 	}
 }
+
+func GetHistoryStyle(row, col int) lipgloss.Style {
+	if row == 0 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("99")).Bold(true).Align(lipgloss.Center)
+	}
+
+	switch col {
+	case 0:
+		return lipgloss.NewStyle().Width(6).Align(lipgloss.Center)
+	case 2:
+		return lipgloss.NewStyle().Width(20)
+	default:
+		return lipgloss.NewStyle().Width(17).Align(lipgloss.Center)
+	}
+}
+
+func GetYearHistoryStyle(row, col int) lipgloss.Style {
+	if row == 0 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("99")).Bold(true).Align(lipgloss.Center)
+	}
+
+	switch col {
+	case 0, 1:
+		return lipgloss.NewStyle().Width(6).Align(lipgloss.Center)
+	case 5:
+		return lipgloss.NewStyle().Width(17).Align(lipgloss.Center)
+	default:
+		return lipgloss.NewStyle().Width(8).Align(lipgloss.Center)
+	}
+}
+
+func GetBenchmarkStyle(row, col int) lipgloss.Style {
+	if row == 0 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("99")).Bold(true).Align(lipgloss.Center)
+	}
+
+	switch col {
+	case 0, 2:
+		return lipgloss.NewStyle().Width(6).Align(lipgloss.Center)
+	case 1:
+		return lipgloss.NewStyle().Width(17).Align(lipgloss.Center)
+	default:
+		return lipgloss.NewStyle().Width(11).Align(lipgloss.Right)
+	}
+}
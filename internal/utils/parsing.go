@@ -4,21 +4,158 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
+
+	"go.dalton.dog/aocgo/internal/config"
 )
 
 const (
 	FIRST_YEAR = 2015
+
+	// Most days any event has had, which every event had before it was shortened
+	MAX_DAYS = 25
+
+	// Starting in 2025, the event only runs for the first 12 days of December
+	SHORT_EVENT_YEAR = 2025
+	SHORT_EVENT_DAYS = 12
 )
 
+// ANSWER_MARKER starts a line of solution output that holds an answer, like "ANSWER: 42" or "ANSWER 2: 42"
+const ANSWER_MARKER = "ANSWER"
+
+var answerLineRegex = regexp.MustCompile(`^\s*` + ANSWER_MARKER + `\s*([12])?\s*:\s*(.*)$`)
+
+// Matches the placeholders in a layout, like "{year}" or "{day:02}"
+var layoutTokenRegex = regexp.MustCompile(`\{(year|day)(?::(\d+))?\}`)
+
+// GetYearAndDayFromCWD finds the year and day from the working directory.
+// If the project config has a layout, the directory is matched against it first.
+// Otherwise, the current directory is the day and its parent is the year.
 func GetYearAndDayFromCWD() (int, int, error) {
 	cwd, err := os.Getwd()
 	if err != nil {
 		return 0, 0, err
 	}
+
+	conf, err := config.Load()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var layoutErr error
+	if conf.Layout != "" {
+		var year, day int
+		year, day, layoutErr = GetYearAndDayFromProjectPath(conf, cwd)
+		if layoutErr == nil {
+			return year, day, nil
+		}
+	}
+
+	year, day, err := getYearAndDayFromDirs(cwd)
+	if err != nil && layoutErr != nil {
+		return 0, 0, layoutErr
+	}
+	return year, day, err
+}
+
+// GetYearAndDayFromProjectPath matches a path inside of the project against the config's layout
+func GetYearAndDayFromProjectPath(conf *config.Config, path string) (int, int, error) {
+	rel, err := filepath.Rel(conf.Root(), path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+		return 0, 0, fmt.Errorf("%v is outside of the project at %v", path, conf.Root())
+	}
+
+	return GetYearAndDayFromLayout(conf.Layout, filepath.ToSlash(rel))
+}
+
+// GetYearAndDayFromLayout matches a slash separated path against a layout like "{year}/day{day:02}".
+// The path can go deeper than the layout, so any directory inside of a day's directory still matches.
+// "{day:02}" only matches zero-padded days, while "{day}" matches either.
+func GetYearAndDayFromLayout(layout, path string) (int, int, error) {
+	layoutRegex, err := compileLayout(layout)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	match := layoutRegex.FindStringSubmatch(path)
+	if match == nil {
+		return 0, 0, fmt.Errorf("%v doesn't match the layout %v", path, layout)
+	}
+
+	year, err := ParseYear(match[layoutRegex.SubexpIndex("year")])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	day, err := ParseDay(match[layoutRegex.SubexpIndex("day")])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return year, day, CheckDay(year, day)
+}
+
+// FormatLayout fills in the year and day placeholders of a layout, zero-padding any that have a width like "{day:02}"
+func FormatLayout(layout string, year, day int) string {
+	return layoutTokenRegex.ReplaceAllStringFunc(layout, func(token string) string {
+		match := layoutTokenRegex.FindStringSubmatch(token)
+
+		value := year
+		if match[1] == "day" {
+			value = day
+		}
+
+		width, _ := strconv.Atoi(match[2])
+		return fmt.Sprintf("%0*d", width, value)
+	})
+}
+
+// Turns a layout into a regex with named groups for the year and day
+func compileLayout(layout string) (*regexp.Regexp, error) {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+
+	found := map[string]bool{}
+	last := 0
+	for _, loc := range layoutTokenRegex.FindAllStringSubmatchIndex(layout, -1) {
+		pattern.WriteString(regexp.QuoteMeta(layout[last:loc[0]]))
+		last = loc[1]
+
+		name := layout[loc[2]:loc[3]]
+		if found[name] {
+			return nil, fmt.Errorf("Layout %v has more than one {%v}", layout, name)
+		}
+		found[name] = true
+
+		width := 0
+		if loc[4] != -1 {
+			width, _ = strconv.Atoi(layout[loc[4]:loc[5]])
+		}
+
+		switch {
+		case name == "year":
+			pattern.WriteString(`(?P<year>\d{4}|\d{2})`)
+		case width > 1:
+			pattern.WriteString(fmt.Sprintf(`(?P<day>\d{%v})`, width))
+		default:
+			pattern.WriteString(`(?P<day>\d{1,2})`)
+		}
+	}
+	pattern.WriteString(regexp.QuoteMeta(layout[last:]))
+	pattern.WriteString("(?:/|$)")
+
+	if !found["year"] || !found["day"] {
+		return nil, fmt.Errorf("Layout %v needs both a {year} and a {day}", layout)
+	}
+
+	return regexp.Compile(pattern.String())
+}
+
+func getYearAndDayFromDirs(cwd string) (int, int, error) {
 	splitCWD := strings.Split(cwd, string(os.PathSeparator))
 
 	cwdLen := len(splitCWD)
@@ -38,7 +175,23 @@ func GetYearAndDayFromDirInput(curDir, parentDir string) (int, int, error) {
 		return day, 0, err
 	}
 
-	return year, day, nil
+	return year, day, CheckDay(year, day)
+}
+
+// GetNumDays returns the number of days in a year's event
+func GetNumDays(year int) int {
+	if year >= SHORT_EVENT_YEAR {
+		return SHORT_EVENT_DAYS
+	}
+	return MAX_DAYS
+}
+
+// CheckDay returns an error if the day isn't part of the year's event, like day 20 of an event with 12 days
+func CheckDay(year, day int) error {
+	if numDays := GetNumDays(year); day < 1 || day > numDays {
+		return fmt.Errorf("Day %v isn't part of %v's event, which has %v days.", day, year, numDays)
+	}
+	return nil
 }
 
 func ParseYear(yearStr string) (int, error) {
@@ -64,12 +217,8 @@ func ParseYear(yearStr string) (int, error) {
 		return 0, errors.New(fmt.Sprintf("Year parsed to be earlier than %v.", FIRST_YEAR))
 	}
 
-	var maxYear int
-	if time.Now().Month() == time.December {
-		maxYear = time.Now().Year()
-	} else {
-		maxYear = time.Now().Year() - 1
-	}
+	// Puzzles unlock in New York time, so the latest year can start before it's December locally
+	maxYear, _ := GetCurrentMaxYearAndDay()
 
 	if outYear > maxYear {
 		return 0, errors.New(fmt.Sprintf("Year parsed to be later than %v.", maxYear))
@@ -89,8 +238,8 @@ func ParseDay(dayStr string) (int, error) {
 
 	if outInt < 1 {
 		return 0, errors.New("Day parsed to be less than 1.")
-	} else if outInt > 25 {
-		return 0, errors.New("Day parsed to be greater than 25.")
+	} else if outInt > MAX_DAYS {
+		return 0, errors.New(fmt.Sprintf("Day parsed to be greater than %v.", MAX_DAYS))
 	}
 
 	return outInt, nil
@@ -194,3 +343,36 @@ func parseWordNumber(word string) int {
 		return 0
 	}
 }
+
+// FindMarkedAnswer finds the answer in a solution's output, along with the part it was marked for (0 if none).
+// If part is 0, the last marked answer is used. Otherwise, the last answer marked
+// for that part is used, falling back to the last answer that wasn't marked with a part.
+func FindMarkedAnswer(output string, part int) (string, int, bool) {
+	var answer, unmarked string
+	var answerPart int
+
+	for _, line := range strings.Split(output, "\n") {
+		match := answerLineRegex.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if match == nil {
+			continue
+		}
+
+		value := strings.TrimSpace(match[2])
+		if value == "" {
+			continue
+		}
+
+		linePart, _ := strconv.Atoi(match[1])
+		if part == 0 || linePart == part {
+			answer, answerPart = value, linePart
+		} else if linePart == 0 {
+			unmarked = value
+		}
+	}
+
+	if answer == "" && unmarked != "" {
+		return unmarked, 0, true
+	}
+
+	return answer, answerPart, answer != ""
+}
@@ -66,3 +66,89 @@ func TestParseDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestFindMarkedAnswer(t *testing.T) {
+	output := "Parsing input...\nANSWER 1: 1234\nsome debug ANSWER: 9\nANSWER 2:  abc \nANSWER: 42\n"
+
+	testCases := []struct {
+		output string
+		part   int
+		answer string
+		marked int
+		found  bool
+	}{
+		{output, 0, "42", 0, true},
+		{output, 1, "1234", 1, true},
+		{output, 2, "abc", 2, true},
+		{"ANSWER 1: 1234\nANSWER: 42", 2, "42", 0, true},
+		{"ANSWER 1: 1234", 2, "", 0, false},
+		{"ANSWER:   \nno answers here", 0, "", 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%q part %v", tc.output, tc.part), func(t *testing.T) {
+			answer, marked, found := FindMarkedAnswer(tc.output, tc.part)
+			if answer != tc.answer || marked != tc.marked || found != tc.found {
+				t.Errorf("Expected (%q, %v, %v), got (%q, %v, %v)", tc.answer, tc.marked, tc.found, answer, marked, found)
+			}
+		})
+	}
+}
+
+func TestGetYearAndDayFromLayout(t *testing.T) {
+	var tests = []struct {
+		layout, path string
+		year, day    int
+	}{
+		{"{year}/day{day:02}", "2023/day05", 2023, 5},
+		{"{year}/day{day:02}", "2023/day05/sub/dir", 2023, 5},
+		{"{year}/day{day}", "2023/day5", 2023, 5},
+		{"cmd/{year}-{day:02}", "cmd/2023-12", 2023, 12},
+		{"solutions/{year}/{day}", "solutions/16/7", 2016, 7},
+		{"{year}{day:02}", "202309", 2023, 9},
+	}
+
+	for _, test := range tests {
+		t.Run(test.layout+" "+test.path, func(t *testing.T) {
+			year, day, err := GetYearAndDayFromLayout(test.layout, test.path)
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+
+			if year != test.year || day != test.day {
+				t.Errorf("Expected %v/%v, got %v/%v", test.year, test.day, year, day)
+			}
+		})
+	}
+
+	var failures = []struct{ layout, path string }{
+		{"{year}/day{day:02}", "2023/day5"},
+		{"{year}/day{day:02}", "2023"},
+		{"{year}/day{day:02}", "other/2023/day05"},
+		{"{year}/day{day:02}", "2023/day055"},
+		{"{year}/days", "2023/days"},
+	}
+
+	for _, test := range failures {
+		if _, _, err := GetYearAndDayFromLayout(test.layout, test.path); err == nil {
+			t.Errorf("Expected %v to not match %v", test.path, test.layout)
+		}
+	}
+}
+
+func TestFormatLayout(t *testing.T) {
+	var tests = []struct {
+		layout, expected string
+	}{
+		{"{year}/day{day:02}", "2023/day05"},
+		{"{year}/{day}", "2023/5"},
+		{"cmd/{year}-{day:03}/input.txt", "cmd/2023-005/input.txt"},
+		{"input.txt", "input.txt"},
+	}
+
+	for _, test := range tests {
+		if got := FormatLayout(test.layout, 2023, 5); got != test.expected {
+			t.Errorf("Expected %v to format to %v, got %v", test.layout, test.expected, got)
+		}
+	}
+}
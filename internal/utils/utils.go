@@ -34,16 +34,30 @@ func GetCurrentMaxYearAndDay() (int, int) {
 
 	nowYear, nowMonth, nowDay := time.Now().In(loc).Date()
 	if nowMonth != time.December {
-		return nowYear - 1, 25
+		return nowYear - 1, GetNumDays(nowYear - 1)
 	} else {
-		if nowDay > 24 {
-			return nowYear, 25
-		} else {
-			return nowYear, nowDay
-		}
+		return nowYear, min(nowDay, GetNumDays(nowYear))
 	}
 }
 
+// GetNextUnlock returns the date of the next puzzle to unlock after the given time, and when it unlocks.
+// Puzzles unlock at midnight in America/New_York, every day from December 1st to the last day of that year's event.
+func GetNextUnlock(now time.Time) (int, int, time.Time) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		log.Fatal("Error loading location:", err)
+	}
+
+	year, month, day := now.In(loc).Date()
+	if month == time.December && day < GetNumDays(year) {
+		return year, day + 1, time.Date(year, time.December, day+1, 0, 0, 0, 0, loc)
+	} else if month == time.December {
+		year++
+	}
+
+	return year, 1, time.Date(year, time.December, 1, 0, 0, 0, 0, loc)
+}
+
 func LaunchURL(url string) error {
 	var cmd string
 	var args []string
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestGetNextUnlock(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name      string
+		now       time.Time
+		year, day int
+		unlock    time.Time
+	}{
+		{"Before the event", time.Date(2024, time.June, 10, 12, 0, 0, 0, loc), 2024, 1, time.Date(2024, time.December, 1, 0, 0, 0, 0, loc)},
+		{"Right before day 1", time.Date(2024, time.November, 30, 23, 59, 59, 0, loc), 2024, 1, time.Date(2024, time.December, 1, 0, 0, 0, 0, loc)},
+		{"Right as day 1 unlocks", time.Date(2024, time.December, 1, 0, 0, 0, 0, loc), 2024, 2, time.Date(2024, time.December, 2, 0, 0, 0, 0, loc)},
+		{"During the event", time.Date(2024, time.December, 14, 18, 30, 0, 0, loc), 2024, 15, time.Date(2024, time.December, 15, 0, 0, 0, 0, loc)},
+		{"After the last day", time.Date(2024, time.December, 25, 1, 0, 0, 0, loc), 2025, 1, time.Date(2025, time.December, 1, 0, 0, 0, 0, loc)},
+		{"During a short event", time.Date(2025, time.December, 11, 9, 0, 0, 0, loc), 2025, 12, time.Date(2025, time.December, 12, 0, 0, 0, 0, loc)},
+		{"After a short event", time.Date(2025, time.December, 12, 9, 0, 0, 0, loc), 2026, 1, time.Date(2026, time.December, 1, 0, 0, 0, 0, loc)},
+		{"Already midnight in UTC", time.Date(2024, time.December, 5, 3, 0, 0, 0, time.UTC), 2024, 5, time.Date(2024, time.December, 5, 0, 0, 0, 0, loc)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			year, day, unlock := GetNextUnlock(test.now)
+			if year != test.year || day != test.day {
+				t.Errorf("Date mismatch. Got %d/%d, expected %d/%d", year, day, test.year, test.day)
+			}
+
+			if !unlock.Equal(test.unlock) {
+				t.Errorf("Unlock mismatch. Got %v, expected %v", unlock, test.unlock)
+			}
+		})
+	}
+}
+
+func TestCheckDay(t *testing.T) {
+	var tests = []struct {
+		year, day int
+		valid     bool
+	}{
+		{2015, 1, true},
+		{2024, 25, true},
+		{2024, 26, false},
+		{2025, 12, true},
+		{2025, 13, false},
+		{2025, 25, false},
+		{2025, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v/%v", test.year, test.day), func(t *testing.T) {
+			err := CheckDay(test.year, test.day)
+			if test.valid && err != nil {
+				t.Errorf("Got unexpected error: %v", err)
+			} else if !test.valid && err == nil {
+				t.Errorf("Expected an error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package aocgo
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"go.dalton.dog/aocgo/internal/config"
+	"go.dalton.dog/aocgo/internal/utils"
+)
+
+// Environment variables that set the puzzle's year and day, which aocli run passes to solutions
+const (
+	YEAR_ENV = "AOC_YEAR"
+	DAY_ENV  = "AOC_DAY"
+)
+
+// Finds the year and day of the puzzle being solved. In order, it's taken from:
+//   - The AOC_YEAR and AOC_DAY environment variables
+//   - The solution's source file, matched against the layout in .aocli.toml
+//   - The working directory, matched against the layout in .aocli.toml or the default year/day layout
+func getYearAndDay() (int, int, error) {
+	if year, day, ok, err := getYearAndDayFromEnv(); ok || err != nil {
+		return year, day, err
+	}
+
+	if year, day, ok := getYearAndDayFromSource(); ok {
+		return year, day, nil
+	}
+
+	return utils.GetYearAndDayFromCWD()
+}
+
+// Reads the year and day from the environment. Returns false if either isn't set.
+func getYearAndDayFromEnv() (int, int, bool, error) {
+	yearEnv, dayEnv := strings.TrimSpace(os.Getenv(YEAR_ENV)), strings.TrimSpace(os.Getenv(DAY_ENV))
+	if yearEnv == "" || dayEnv == "" {
+		return 0, 0, false, nil
+	}
+
+	year, err := utils.ParseYear(yearEnv)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	day, err := utils.ParseDay(dayEnv)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	return year, day, true, utils.CheckDay(year, day)
+}
+
+// Matches the file that the solution's main package is in against the project's layout.
+// This lets flat layouts like "2023/day05.go" work, where the working directory doesn't say which day it is.
+// Returns false without a layout, or if the binary was built without file paths.
+func getYearAndDayFromSource() (int, int, bool) {
+	conf, err := config.Load()
+	if err != nil || conf.Layout == "" {
+		return 0, 0, false
+	}
+
+	file := findMainFile()
+	if file == "" || !filepath.IsAbs(file) {
+		return 0, 0, false
+	}
+
+	// Try the file itself, then without its extension, so "{year}/day{day:02}" matches "2023/day05.go"
+	for _, path := range []string{file, strings.TrimSuffix(file, filepath.Ext(file))} {
+		if year, day, err := utils.GetYearAndDayFromProjectPath(conf, path); err == nil {
+			return year, day, true
+		}
+	}
+
+	return 0, 0, false
+}
+
+// Finds the source file of the closest caller in the main package
+func findMainFile() string {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, "main.") {
+			return filepath.FromSlash(frame.File)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
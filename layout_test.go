@@ -0,0 +1,22 @@
+package aocgo
+
+import "testing"
+
+func TestGetYearAndDayFromEnv(t *testing.T) {
+	t.Setenv(YEAR_ENV, "2016")
+	t.Setenv(DAY_ENV, "")
+	if _, _, ok, err := getYearAndDayFromEnv(); ok || err != nil {
+		t.Errorf("Expected the environment to be skipped without a day, got %v (err: %v)", ok, err)
+	}
+
+	t.Setenv(DAY_ENV, "07")
+	year, day, err := getYearAndDay()
+	if err != nil || year != 2016 || day != 7 {
+		t.Errorf("Expected 2016/7 from the environment, got %v/%v (err: %v)", year, day, err)
+	}
+
+	t.Setenv(DAY_ENV, "30")
+	if _, _, _, err := getYearAndDayFromEnv(); err == nil {
+		t.Errorf("Expected an error for an invalid day")
+	}
+}
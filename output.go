@@ -0,0 +1,108 @@
+package aocgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PART_ENV is the environment variable that aocli run and aocli bench use to pick a single part to run.
+// If it's set to 1 or 2, RunSolve, RunSolveAndSubmit, and Benchmark skip solvers for the other part.
+const PART_ENV = "AOC_PART"
+
+// OUTPUT_ENV is the environment variable that picks how RunSolve and RunTest print their results.
+// Set it to "json" to print a single Result per line instead of a styled box.
+const OUTPUT_ENV = "AOCGO_OUTPUT"
+
+// Kinds of results printed by RunSolve, RunTest, and Benchmark
+const (
+	SOLVE_RESULT = "solve"
+	TEST_RESULT  = "test"
+	BENCH_RESULT = "bench"
+)
+
+// Result is a single line of RunSolve or RunTest output in JSON mode
+type Result struct {
+	// One of SOLVE_RESULT, TEST_RESULT, or BENCH_RESULT
+	Kind  string `json:"kind"`
+	Title string `json:"title"`
+
+	// Part of the puzzle, taken from the title (like "Part 1" or "Part B"). 0 if it couldn't be found.
+	Part int `json:"part"`
+
+	Answer   string `json:"answer"`
+	Expected string `json:"expected,omitempty"`
+
+	// Only meaningful for tests
+	Passed bool `json:"passed"`
+
+	// Runtime in nanoseconds. The median runtime for benchmarks.
+	Runtime time.Duration `json:"runtime"`
+
+	// Outcome of submitting the answer, only set by RunSolveAndSubmit
+	Outcome string `json:"outcome,omitempty"`
+
+	// Why a test couldn't finish, like a panic or timeout. Only set by RunTests.
+	Error string `json:"error,omitempty"`
+
+	// Stats from a benchmark, and the stored benchmark before it. Only set by Benchmark.
+	Benchmark *BenchmarkStats `json:"benchmark,omitempty"`
+	Previous  *BenchmarkStats `json:"previous,omitempty"`
+}
+
+var partTitleRegex = regexp.MustCompile(`(?i)\bpart\s*(1|2|a|b|one|two)\b`)
+
+// ParseResult parses a line of JSON output. Returns false if the line isn't a result.
+func ParseResult(line string) (Result, bool) {
+	var result Result
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "{") || json.Unmarshal([]byte(line), &result) != nil {
+		return Result{}, false
+	}
+
+	return result, result.Kind == SOLVE_RESULT || result.Kind == TEST_RESULT || result.Kind == BENCH_RESULT
+}
+
+// Returns true if results should be printed as JSON
+func jsonOutput() bool {
+	return strings.EqualFold(strings.TrimSpace(os.Getenv(OUTPUT_ENV)), "json")
+}
+
+// Prints a result as a single line of JSON
+func printJSONResult(result Result) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// Finds which part of the puzzle a title is for
+func partFromTitle(title string) int {
+	match := partTitleRegex.FindStringSubmatch(title)
+	if match == nil {
+		return 0
+	}
+
+	switch strings.ToLower(match[1]) {
+	case "1", "a", "one":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Returns false if PART_ENV picks a different part than the given one.
+// Solvers whose part isn't known are always run.
+func partRequested(part int) bool {
+	requested, err := strconv.Atoi(strings.TrimSpace(os.Getenv(PART_ENV)))
+	if err != nil || requested == 0 || part == 0 {
+		return true
+	}
+	return requested == part
+}
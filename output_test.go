@@ -0,0 +1,70 @@
+package aocgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPartFromTitle(t *testing.T) {
+	var tests = []struct {
+		title string
+		part  int
+	}{
+		{"Part 1", 1},
+		{"part B", 2},
+		{"Test Part One", 1},
+		{"Solve (Part 2)", 2},
+		{"Partial", 0},
+		{"Example", 0},
+	}
+
+	for _, test := range tests {
+		if part := partFromTitle(test.title); part != test.part {
+			t.Errorf("Expected %q to be part %v, got %v", test.title, test.part, part)
+		}
+	}
+}
+
+func TestPartRequested(t *testing.T) {
+	t.Setenv(PART_ENV, "2")
+	t.Setenv(OUTPUT_ENV, "json")
+
+	if partRequested(1) || !partRequested(2) || !partRequested(0) {
+		t.Errorf("Expected only part 2 and unknown parts to be run")
+	}
+
+	calls := 0
+	solver := func(input string) int {
+		calls++
+		return len(input)
+	}
+
+	RunSolve("Part 1", solver, "abc")
+	if stats := Benchmark("Part A", solver, "abc"); calls != 0 || stats.Runs != 0 {
+		t.Errorf("Expected part 1's solvers to be skipped, got %v calls", calls)
+	}
+
+	t.Setenv(PART_ENV, "0")
+	if !partRequested(1) || !partRequested(2) {
+		t.Errorf("Expected every part to be run when no part is picked")
+	}
+}
+
+func TestParseResult(t *testing.T) {
+	line := `{"kind":"test","title":"Part A","part":1,"answer":"5","expected":"6","passed":false,"runtime":1500}`
+
+	result, ok := ParseResult(line)
+	if !ok {
+		t.Fatalf("Expected %v to be parsed as a result", line)
+	}
+
+	if result.Kind != TEST_RESULT || result.Part != 1 || result.Answer != "5" || result.Passed || result.Runtime != 1500*time.Nanosecond {
+		t.Errorf("Result wasn't parsed correctly, got %+v", result)
+	}
+
+	for _, line := range []string{"Answer: 5", `{"some":"json"}`, "{not json"} {
+		if _, ok := ParseResult(line); ok {
+			t.Errorf("Expected %q to not be parsed as a result", line)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package aocgo
+
+import (
+	"fmt"
+	"time"
+
+	"go.dalton.dog/aocgo/internal/resources"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+)
+
+// SubmissionResult is the result of submitting an answer.
+// Check its Outcome against the Outcome constants.
+type SubmissionResult = resources.SubmissionResult
+
+// Outcome is what happened when an answer was submitted
+type Outcome = resources.Outcome
+
+// Outcomes of submitting an answer
+const (
+	OutcomeUnknown       = resources.OutcomeUnknown
+	OutcomeCorrect       = resources.OutcomeCorrect
+	OutcomeWrong         = resources.OutcomeWrong
+	OutcomeTooHigh       = resources.OutcomeTooHigh
+	OutcomeTooLow        = resources.OutcomeTooLow
+	OutcomeRateLimited   = resources.OutcomeRateLimited
+	OutcomeWrongLevel    = resources.OutcomeWrongLevel
+	OutcomeAlreadySolved = resources.OutcomeAlreadySolved
+	OutcomeNotLoggedIn   = resources.OutcomeNotLoggedIn
+	OutcomePuzzleLocked  = resources.OutcomePuzzleLocked
+	OutcomeDuplicate     = resources.OutcomeDuplicate
+	OutcomeOutOfBounds   = resources.OutcomeOutOfBounds
+)
+
+// Submit will submit an answer to a part of the puzzle, as determined by the file's working directory.
+// Pass in 0 for the part to submit to the first part that doesn't have a star yet.
+// Answers that are already known to be right or wrong are checked against the stored puzzle and never sent to the site.
+// Nothing is submitted while -input or AOCGO_INPUT points at another input, since the answer isn't for the user's input.
+func Submit[Out AnswerData](part int, answer Out) (SubmissionResult, error) {
+	year, day, err := getYearAndDay()
+	if err != nil {
+		return SubmissionResult{}, err
+	}
+
+	if path := getInputOverride(); path != "" {
+		log.Warn("Not submitting, since the answer is for another input.", "input", path)
+		return SubmissionResult{}, nil
+	}
+
+	return submit(year, day, part, fmt.Sprintf("%v", answer))
+}
+
+// RunSolveAndSubmit works like RunSolve, then submits the answer to the given part and prints the verdict.
+// Pass in 0 for the part to submit to the first part that doesn't have a star yet.
+// If AOC_PART is set to a different part than the given one (or the one in the title), the solver isn't run,
+// and if -input or AOCGO_INPUT is set, the answer is shown without being submitted.
+func RunSolveAndSubmit[In InputData, Out AnswerData](title string, part int, solver Solver[In, Out], inputData In) {
+	resultPart := part
+	if resultPart == 0 {
+		resultPart = partFromTitle(title)
+	}
+
+	if !partRequested(resultPart) {
+		return
+	}
+
+	start := time.Now()
+
+	answer := solver(inputData)
+
+	timeTaken := time.Since(start)
+
+	// Convert the answer to a string
+	answerStr := fmt.Sprintf("%v", answer)
+
+	result, err := Submit(part, answer)
+	if err != nil {
+		log.Fatal("Unable to submit answer.", "err", err)
+	}
+
+	if jsonOutput() {
+		printJSONResult(Result{
+			Kind:    SOLVE_RESULT,
+			Title:   title,
+			Part:    resultPart,
+			Answer:  answerStr,
+			Runtime: timeTaken,
+			Outcome: result.Outcome.String(),
+		})
+		return
+	}
+
+	var outColor lipgloss.Color
+	switch {
+	case result.Outcome == OutcomeCorrect:
+		outColor = correctTestColor
+	case result.Outcome.IsIncorrect():
+		outColor = incorrectTestColor
+	default:
+		outColor = puzzleSolveColor
+	}
+
+	// Pretty info
+	prettyAnswer := fmt.Sprintf("Answer : %v\n", answerStr)
+	prettyTime := fmt.Sprintf("Runtime: %v\n", timeTaken)
+	prettyVerdict := fmt.Sprintf("Verdict: %v", result.Outcome)
+	if !result.Submitted {
+		prettyVerdict += " (not submitted)"
+	}
+	if result.Wait > 0 {
+		prettyVerdict += fmt.Sprintf("\nWait   : %v", result.Wait.Round(time.Second))
+	}
+
+	fmt.Println(renderBox(title, prettyAnswer+prettyTime+prettyVerdict, outColor))
+}
+
+func submit(year, day, part int, answer string) (SubmissionResult, error) {
+	if part < 0 || part > 2 {
+		return SubmissionResult{}, fmt.Errorf("Part %v is not a valid puzzle part", part)
+	}
+
+	var result SubmissionResult
+	err := usePuzzle(year, day, func(puzzle *resources.Puzzle) error {
+		var err error
+		result, err = puzzle.SubmitAnswer(answer, part)
+		return err
+	})
+
+	return result, err
+}
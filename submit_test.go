@@ -0,0 +1,85 @@
+package aocgo
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.dalton.dog/aocgo/aocfake"
+	"go.dalton.dog/aocgo/internal/api"
+	"go.dalton.dog/aocgo/internal/cache"
+)
+
+// Starts a fake server and points aocgo and its cache at it
+func startFakeServer(t *testing.T) (*aocfake.Server, *httptest.Server) {
+	fake := aocfake.New()
+	server := httptest.NewServer(fake)
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("AOC_SESSION_TOKEN", fake.Token)
+	t.Setenv("AOC_BASE_URL", server.URL)
+
+	backupCacheFile := cache.CacheFile
+	cache.CacheFile = filepath.Join(t.TempDir(), "%v.db")
+	t.Cleanup(func() {
+		server.Close()
+		cache.CacheFile = backupCacheFile
+		api.MasterClient = nil
+	})
+
+	return fake, server
+}
+
+func TestSubmit(t *testing.T) {
+	startFakeServer(t)
+
+	result, err := submit(2015, 1, 0, "101587")
+	if err != nil || result.Outcome != OutcomeCorrect || !result.Submitted {
+		t.Fatalf("Expected a correct answer, got %+v (err: %v)", result, err)
+	}
+
+	// The puzzle should remember the star, so the answer isn't submitted again
+	result, err = submit(2015, 1, 1, "101587")
+	if err != nil || result.Outcome != OutcomeAlreadySolved || result.Submitted {
+		t.Errorf("Expected the answer to be known as correct, got %+v (err: %v)", result, err)
+	}
+
+	result, err = submit(2015, 1, 2, "5")
+	if err != nil || result.Outcome != OutcomeTooLow {
+		t.Errorf("Expected an answer that's too low, got %+v (err: %v)", result, err)
+	}
+
+	if _, err := submit(2015, 1, 3, "5"); err == nil {
+		t.Errorf("Expected an error for an invalid part")
+	}
+}
+
+func TestRunSolveAndSubmitOverride(t *testing.T) {
+	fake, _ := startFakeServer(t)
+
+	dir := filepath.Join(t.TempDir(), "2015", "1")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd)
+
+	t.Setenv(YEAR_ENV, "")
+	t.Setenv(DAY_ENV, "")
+	t.Setenv(PART_ENV, "")
+	t.Setenv(INPUT_ENV, filepath.Join(dir, "example.txt"))
+
+	// Even the right answer shouldn't be sent when it came from another input
+	RunSolveAndSubmit("Part 1", 1, func(string) string { return fake.GetPuzzle(2015, 1).AnswerOne }, "")
+
+	if fake.Stars() != 0 {
+		t.Errorf("Expected nothing to be submitted with %v set", INPUT_ENV)
+	}
+}
@@ -0,0 +1,209 @@
+package aocgo
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+)
+
+// TestCase is a single named case for RunTests
+type TestCase[In InputData, Out AnswerData] struct {
+	Name string
+
+	// Input data for the case. Ignored if File is set.
+	Input In
+
+	// Path to a file to load the input data from, like "example1.txt"
+	File string
+
+	Expected Out
+
+	// How long the solver can run before the case fails. No limit if 0.
+	Timeout time.Duration
+}
+
+// Outcome of running a single TestCase
+type caseResult struct {
+	name     string
+	answer   string
+	expected string
+	passed   bool
+	runtime  time.Duration
+	err      error
+}
+
+// RunTests will run the solver against every case, then print a summary table of the results.
+// Panics in the solver are recovered and count as a failure, as do cases that go over their timeout.
+// Returns 0 if every case passed, and 1 otherwise, so it can be passed straight to os.Exit:
+//
+//	os.Exit(aocgo.RunTests(partA, cases))
+//
+// If AOCGO_OUTPUT is set to "json", each case is printed as a single line of JSON instead.
+func RunTests[In InputData, Out AnswerData](solver Solver[In, Out], cases []TestCase[In, Out]) int {
+	exitCode := 0
+	results := make([]caseResult, 0, len(cases))
+
+	for _, tc := range cases {
+		result := runTestCase(solver, tc)
+		if !result.passed {
+			exitCode = 1
+		}
+
+		results = append(results, result)
+	}
+
+	if jsonOutput() {
+		for _, result := range results {
+			printJSONResult(result.toResult())
+		}
+	} else {
+		fmt.Println(renderTestSummary(results))
+	}
+
+	return exitCode
+}
+
+// Runs a single case, recovering from any panics and stopping at the case's timeout
+func runTestCase[In InputData, Out AnswerData](solver Solver[In, Out], tc TestCase[In, Out]) caseResult {
+	result := caseResult{
+		name:     tc.Name,
+		expected: fmt.Sprintf("%v", tc.Expected),
+	}
+
+	inputData := tc.Input
+	if tc.File != "" {
+		data, err := os.ReadFile(tc.File)
+		if err != nil {
+			result.err = err
+			return result
+		}
+		inputData, err = convertInput[In](data)
+		if err != nil {
+			result.err = err
+			return result
+		}
+	}
+
+	type solveResult struct {
+		answer  Out
+		runtime time.Duration
+		err     error
+	}
+
+	done := make(chan solveResult, 1)
+	go func() {
+		start := time.Now()
+		defer func() {
+			if r := recover(); r != nil {
+				done <- solveResult{runtime: time.Since(start), err: fmt.Errorf("panic: %v", r)}
+			}
+		}()
+
+		answer := solver(inputData)
+		done <- solveResult{answer: answer, runtime: time.Since(start)}
+	}()
+
+	// A nil channel never receives, so there's no limit without a timeout
+	var timeout <-chan time.Time
+	if tc.Timeout > 0 {
+		timeout = time.After(tc.Timeout)
+	}
+
+	select {
+	case solved := <-done:
+		result.runtime = solved.runtime
+		result.err = solved.err
+		if solved.err == nil {
+			result.answer = fmt.Sprintf("%v", solved.answer)
+			result.passed = result.answer == result.expected
+		}
+	case <-timeout:
+		// The solver can't be stopped, so it's left to finish in the background
+		result.runtime = tc.Timeout
+		result.err = fmt.Errorf("timed out after %v", tc.Timeout)
+	}
+
+	return result
+}
+
+// Converts raw file data into the form of input the solver takes.
+// Ints are read one per line, and [][]string is read as a character matrix.
+func convertInput[In InputData](data []byte) (In, error) {
+	var input In
+	var err error
+	switch in := any(&input).(type) {
+	case *string:
+		*in = string(data)
+	case *[]string:
+		*in = strings.Split(string(data), "\n")
+	case *[][]string:
+		*in = toCharMatrix(strings.Split(string(data), "\n"))
+	case *[]byte:
+		*in = data
+	case *[][]rune:
+		*in = toRuneMatrix(trimInput(string(data)))
+	case *[]int:
+		*in, err = parseInts(trimInput(string(data)), "\n")
+	}
+	return input, err
+}
+
+func (r caseResult) toResult() Result {
+	result := Result{
+		Kind:     TEST_RESULT,
+		Title:    r.name,
+		Part:     partFromTitle(r.name),
+		Answer:   r.answer,
+		Expected: r.expected,
+		Passed:   r.passed,
+		Runtime:  r.runtime,
+	}
+
+	if r.err != nil {
+		result.Error = r.err.Error()
+	}
+
+	return result
+}
+
+// Renders a table with a row for each case, followed by how many passed
+func renderTestSummary(results []caseResult) string {
+	passStyle := lipgloss.NewStyle().Foreground(correctTestColor)
+	failStyle := lipgloss.NewStyle().Foreground(incorrectTestColor)
+
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(puzzleSolveColor)).
+		Headers("Case", "Result", "Answer", "Expected", "Runtime").
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return lipgloss.NewStyle().Bold(true).Padding(0, 1).Align(lipgloss.Center)
+			}
+			return lipgloss.NewStyle().Padding(0, 1)
+		})
+
+	passed := 0
+	for _, r := range results {
+		status := failStyle.Render("FAIL")
+		answer := r.answer
+		if r.passed {
+			status = passStyle.Render("PASS")
+			passed++
+		} else if r.err != nil {
+			answer = failStyle.Render(r.err.Error())
+		}
+
+		t.Row(r.name, status, answer, r.expected, r.runtime.String())
+	}
+
+	summary := passStyle.Render(fmt.Sprintf("All %v cases passed", len(results)))
+	if passed != len(results) {
+		summary = failStyle.Render(fmt.Sprintf("%v/%v cases passed", passed, len(results)))
+	}
+
+	return t.Render() + "\n" + summary
+}
@@ -0,0 +1,69 @@
+package aocgo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func countLines(lines []string) int {
+	if lines[0] == "panic" {
+		panic("bad input")
+	} else if lines[0] == "slow" {
+		time.Sleep(time.Second)
+	}
+	return len(lines)
+}
+
+func TestRunTests(t *testing.T) {
+	inputFile := filepath.Join(t.TempDir(), "example1.txt")
+	if err := os.WriteFile(inputFile, []byte("a\nb\nc"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []TestCase[[]string, int]{
+		{Name: "Inline", Input: []string{"a", "b"}, Expected: 2},
+		{Name: "File", File: inputFile, Expected: 3},
+		{Name: "Wrong", Input: []string{"a"}, Expected: 2},
+		{Name: "Panic", Input: []string{"panic"}, Expected: 1},
+		{Name: "Timeout", Input: []string{"slow"}, Expected: 1, Timeout: 10 * time.Millisecond},
+		{Name: "Missing file", File: filepath.Join(t.TempDir(), "missing.txt"), Expected: 1},
+	}
+
+	var tests = []struct {
+		passed bool
+		err    string
+	}{
+		{true, ""},
+		{true, ""},
+		{false, ""},
+		{false, "panic: bad input"},
+		{false, "timed out"},
+		{false, "no such file"},
+	}
+
+	for i, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result := runTestCase(countLines, tc)
+			if result.passed != tests[i].passed {
+				t.Errorf("Expected passed to be %v, got %+v", tests[i].passed, result)
+			}
+
+			if tests[i].err == "" && result.err != nil {
+				t.Errorf("Expected no error, got %v", result.err)
+			} else if tests[i].err != "" && (result.err == nil || !strings.Contains(result.err.Error(), tests[i].err)) {
+				t.Errorf("Expected an error containing %q, got %v", tests[i].err, result.err)
+			}
+		})
+	}
+
+	if code := RunTests(countLines, cases[:2]); code != 0 {
+		t.Errorf("Expected an exit code of 0 when every case passes, got %v", code)
+	}
+
+	if code := RunTests(countLines, cases); code != 1 {
+		t.Errorf("Expected an exit code of 1 when a case fails, got %v", code)
+	}
+}